@@ -1,13 +1,55 @@
 package config
 
+import (
+	"time"
+
+	"github.com/joshuamURD/wclist/wclist"
+)
+
 type Config struct {
 	Localhost string
 	Port      string
+
+	// ParseTimeout bounds how long parsing an uploaded cause list may take
+	ParseTimeout time.Duration
+
+	// Limits on uploaded cause lists; zero disables a limit
+	MaxUploadBytes int64
+	MaxPages       int
+	PageTimeout    time.Duration
+
+	// OCR reads scanned pages of uploaded PDFs. When nil, scanned pages are
+	// skipped and listed in the upload's report.
+	OCR wclist.OCR
+
+	// ReviewConfidence is the confidence below which parsed items are
+	// queued for review
+	ReviewConfidence float64
+
+	// BlobDir is the directory uploaded documents are kept in by their
+	// content, so that stored lists can be reprocessed after a parser
+	// change. Documents are kept in memory when it is empty.
+	BlobDir string
+
+	// Parsed documents are cached by content for repeat uploads, in
+	// CacheDir or in memory when it is empty, up to CacheBytes. A zero
+	// CacheBytes disables the cache.
+	CacheDir   string
+	CacheBytes int64
 }
 
 func NewConfig() *Config {
 	return &Config{
-		Localhost: "localhost",
-		Port:      "8080",
+		Localhost:    "localhost",
+		Port:         "8080",
+		ParseTimeout: 30 * time.Second,
+
+		MaxUploadBytes: 50 << 20,
+		MaxPages:       500,
+		PageTimeout:    10 * time.Second,
+
+		ReviewConfidence: wclist.DefaultMinConfidence,
+
+		CacheBytes: 64 << 20,
 	}
 }
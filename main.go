@@ -1,40 +1,402 @@
 package main
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
 	"fmt"
 	"log"
 	"os"
+	"path/filepath"
+	"slices"
+	"strings"
 	"time"
 
+	"github.com/joshuamURD/wclist/config"
+	"github.com/joshuamURD/wclist/server"
 	"github.com/joshuamURD/wclist/wclist"
 )
 
 func main() {
-	// Create a new cause list
-	causeList := wclist.NewCauseList("Queensland", "Brisbane", time.Now())
+	path := flag.String("file", "test/test.pdf", "cause list to read (PDF, HTML or DOCX)")
+	query := flag.String("query", "", `print the items matching a query, e.g. 'type=objection AND objector:Prospector'`)
+	serve := flag.Bool("serve", false, "serve the parsed cause list over HTTP")
+	cache := flag.String("cache", "", "directory to cache parsed cause lists in by content, so unchanged documents aren't parsed again")
+	blobs := flag.String("blobs", "", "directory to keep documents served with -serve in, for reprocessing (default in memory)")
+	export := flag.String("export", "", "export items, or matches with -matters, as csv, json or xlsx")
+	out := flag.String("out", "", "file to write the export to (default cause_list.<format> or matches.<format>)")
+	matters := flag.String("matters", "", "JSON file of assigned matters to search for")
+	password := flag.String("password", "", "password for an encrypted PDF")
+	highlight := flag.String("highlight", "", "write a copy of the PDF with the matches for -matters highlighted to this file")
+	profile := flag.String("profile", "", "layout profile to read with: a registered profile name or a YAML or JSON file (default detected)")
+	strict := flag.Bool("strict", false, "reject rows whose items have a low confidence")
+	ocr := flag.Bool("ocr", false, "read scanned pages of lists uploaded to -serve with tesseract")
+	evaluate := flag.String("evaluate", "", "score the parser against labelled items: a JSON file of the items expected in -file, or a directory of documents each labelled by <name>.json")
+	generate := flag.String("generate", "", "write a synthetic cause list PDF to this file, with its items labelled in <name>.json")
+	seed := flag.Int64("seed", 1, "seed of the cause list written by -generate")
+	generateMatters := flag.Int("generate-matters", 20, "matters in each section of the cause list written by -generate")
+	flag.Parse()
 
-	// Open and read the PDF file
-	file, err := os.Open("test/test.pdf")
+	if *generate != "" {
+		runGenerate(*generate, *seed, *generateMatters)
+		return
+	}
+	if *evaluate != "" {
+		runEvaluate(*evaluate, *path, *password, *profile, *strict)
+		return
+	}
+
+	causeList, err := readCauseList(*path, *password, *profile, *strict, *cache)
+	if err != nil {
+		log.Printf("Error reading cause list: %v", err)
+		os.Exit(exitCode(err))
+	}
+
+	switch {
+	case *highlight != "":
+		runHighlight(causeList, *path, *highlight, *matters)
+	case *export != "":
+		runExport(causeList, *export, *out, *query, *matters)
+	case *query != "":
+		runQuery(causeList, *query)
+	case *serve:
+		cfg := config.NewConfig()
+		cfg.BlobDir = *blobs
+		cfg.CacheDir = *cache
+		if *ocr {
+			cfg.OCR = wclist.TesseractOCR{}
+		}
+		srv := server.NewServer(cfg)
+		if err := srv.LoadLists(); err != nil {
+			log.Fatalf("Error loading stored lists: %v", err)
+		}
+		document, err := os.ReadFile(*path)
+		if err != nil {
+			log.Fatalf("Error reading cause list: %v", err)
+		}
+		srv.AddCauseListDocument(causeList, document)
+		log.Fatal(srv.Start())
+	default:
+		runExample(causeList)
+	}
+}
+
+// cacheBytes limits the size of the -cache directory
+const cacheBytes = 256 << 20
+
+// Exit codes for cause lists that can't be read
+const (
+	exitError         = 1
+	exitNotACauseList = 3
+	exitEncryptedPDF  = 4
+	exitMalformedPDF  = 5
+	exitNoItemsFound  = 6
+	exitTooLarge      = 7
+)
+
+// exitCode chooses the exit code for an error from reading a cause list
+func exitCode(err error) int {
+	switch {
+	case errors.Is(err, wclist.ErrNotACauseList):
+		return exitNotACauseList
+	case errors.Is(err, wclist.ErrEncryptedPDF):
+		return exitEncryptedPDF
+	case errors.Is(err, wclist.ErrMalformedPDF):
+		return exitMalformedPDF
+	case errors.Is(err, wclist.ErrNoItemsFound):
+		return exitNoItemsFound
+	case errors.Is(err, wclist.ErrTooLarge):
+		return exitTooLarge
+	default:
+		return exitError
+	}
+}
+
+// readCauseList parses the cause list document at path, opening encrypted
+// PDFs with the password if one is given. The layout profile is detected
+// unless one is named or given as a file. In strict mode, rows with a low
+// confidence are rejected. With a cache directory, documents parsed before
+// are read from the cache.
+func readCauseList(path, password, profile string, strict bool, cacheDir string) (*wclist.CauseList, error) {
+	// Create a new cause list, released when the document says it was
+	causeList := wclist.NewCauseList("Queensland", "Brisbane", time.Time{})
+
+	// Open and read the cause list file
+	file, err := os.Open(path)
 	if err != nil {
-		log.Fatalf("Error opening PDF file: %v", err)
+		return nil, fmt.Errorf("opening cause list: %w", err)
 	}
 	defer file.Close()
 
 	// Get file size
 	stat, err := file.Stat()
 	if err != nil {
-		log.Fatalf("Error getting file stats: %v", err)
+		return nil, fmt.Errorf("getting file stats: %w", err)
 	}
 
-	// Read the cause list from PDF
-	fmt.Println("Reading cause list from PDF...")
-	err = causeList.ReadCauseList(file, stat.Size())
+	// Read the cause list
+	fmt.Println("Reading cause list...")
+	opts, err := readOptions(password, profile, strict)
 	if err != nil {
-		log.Fatalf("Error reading cause list: %v", err)
+		return nil, err
+	}
+	if cacheDir != "" {
+		opts.Cache = wclist.NewDiskCache(cacheDir, cacheBytes)
+	}
+	if err := causeList.ReadCauseListOptions(context.Background(), file, stat.Size(), opts); err != nil {
+		return nil, err
+	}
+	if causeList.ReleaseDate.IsZero() {
+		causeList.ReleaseDate = time.Now()
 	}
 
 	fmt.Printf("Successfully parsed %d items from the cause list\n", len(causeList.Items))
+	report := causeList.Report
+	fmt.Printf("Read the %s document with the %s profile", report.Format, report.Profile)
+	if report.Pages > 0 {
+		fmt.Printf(", %d pages", report.Pages)
+	}
+	fmt.Println()
+	if len(report.OCRPages) > 0 {
+		fmt.Printf("Read scanned pages %v with OCR\n", report.OCRPages)
+	}
+	if causeList.Report.Cached {
+		fmt.Printf("Read from the cache as %s\n", causeList.Report.CacheKey)
+	}
+	if causeList.Report.CacheError != "" {
+		fmt.Printf("Cache failed: %s\n", causeList.Report.CacheError)
+	}
+	for _, pageErr := range causeList.Report.PageErrors {
+		fmt.Printf("Skipped %v\n", pageErr)
+	}
+	if n := len(causeList.Report.RowErrors); n > 0 {
+		fmt.Printf("Skipped %d rows that couldn't be parsed\n", n)
+	}
+	return causeList, nil
+}
+
+// readOptions returns the options for reading a cause list with the
+// password, profile and strictness given on the command line. Files named
+// on the command line are trusted, so no limits apply.
+func readOptions(password, profile string, strict bool) (wclist.ReadOptions, error) {
+	opts := wclist.ReadOptions{OCR: wclist.TesseractOCR{}}
+	opts.Password = password
+	opts.Strict = strict
+	if profile != "" {
+		var err error
+		if opts.Profile, err = findProfile(profile); err != nil {
+			return opts, err
+		}
+	}
+	return opts, nil
+}
+
+// findProfile returns the registered profile with the given name, or loads
+// the profile from a file
+func findProfile(nameOrPath string) (*wclist.Profile, error) {
+	if p := wclist.ProfileByName(nameOrPath); p != nil {
+		return p, nil
+	}
+	p, err := wclist.LoadProfile(nameOrPath)
+	if err != nil {
+		return nil, fmt.Errorf("loading profile: %w", err)
+	}
+	return p, nil
+}
+
+// runQuery prints the items matching a text query
+func runQuery(causeList *wclist.CauseList, text string) {
+	q, err := wclist.ParseQuery(text)
+	if err != nil {
+		log.Fatalf("Error parsing query: %v", err)
+	}
+
+	items := causeList.Query(q)
+	fmt.Printf("Found %d items matching %q:\n", len(items), text)
+	for _, item := range items {
+		fmt.Printf("  %s %d: %s, %s v %s\n", wclist.ItemType(item), item.GetMatterNumber(),
+			item.GetTenementNumber(), item.GetApplyingParty(), item.GetRespondingParty())
+	}
+}
+
+// runExport writes the items matching an optional query, or the matches for
+// a file of assigned matters whose items match it, to a CSV, JSON or XLSX
+// file
+func runExport(causeList *wclist.CauseList, formatName, out, query, mattersPath string) {
+	format, err := wclist.ParseExportFormat(formatName)
+	if err != nil {
+		log.Fatalf("Error exporting: %v", err)
+	}
+
+	items := causeList.Items
+	var matches []wclist.MatchResult
+	if mattersPath != "" {
+		matches = causeList.SearchAssignedMatters(readAssignedMatters(mattersPath))
+	}
+	if query != "" {
+		q, err := wclist.ParseQuery(query)
+		if err != nil {
+			log.Fatalf("Error parsing query: %v", err)
+		}
+		items = causeList.Query(q)
+		matches = slices.DeleteFunc(matches, func(match wclist.MatchResult) bool { return !q.Match(match.CauseListItem) })
+	}
+
+	if out == "" {
+		out = fmt.Sprintf("cause_list.%s", format)
+		if mattersPath != "" {
+			out = fmt.Sprintf("matches.%s", format)
+		}
+	}
+
+	file, err := os.Create(out)
+	if err != nil {
+		log.Fatalf("Error creating export file: %v", err)
+	}
+	defer file.Close()
+
+	if mattersPath != "" {
+		if err := wclist.WriteMatches(file, format, matches); err != nil {
+			log.Fatalf("Error exporting: %v", err)
+		}
+		fmt.Printf("Exported %d matches to %s\n", len(matches), out)
+		return
+	}
+	if err := wclist.WriteItems(file, format, items); err != nil {
+		log.Fatalf("Error exporting: %v", err)
+	}
+	fmt.Printf("Exported %d items to %s\n", len(items), out)
+}
+
+// runHighlight writes a copy of the PDF cause list at path with the
+// matches for a file of assigned matters highlighted
+func runHighlight(causeList *wclist.CauseList, path, out, mattersPath string) {
+	if mattersPath == "" {
+		log.Fatalf("Error highlighting: -highlight needs a file of assigned matters from -matters")
+	}
+	matches := causeList.SearchAssignedMatters(readAssignedMatters(mattersPath))
+
+	document, err := os.Open(path)
+	if err != nil {
+		log.Fatalf("Error highlighting: %v", err)
+	}
+	defer document.Close()
+	stat, err := document.Stat()
+	if err != nil {
+		log.Fatalf("Error highlighting: %v", err)
+	}
+
+	file, err := os.Create(out)
+	if err != nil {
+		log.Fatalf("Error creating highlighted PDF: %v", err)
+	}
+	defer file.Close()
+
+	if err := wclist.WriteHighlightedPDF(file, document, stat.Size(), matches); err != nil {
+		log.Fatalf("Error highlighting: %v", err)
+	}
+	fmt.Printf("Highlighted %d matches in %s\n", len(matches), out)
+}
+
+// runEvaluate scores the parser against labelled items and prints the
+// report. labels is either the labels of the document at path, or a
+// directory in which each document is labelled by a JSON file with the same
+// name, e.g. cause_list.pdf by cause_list.json.
+func runEvaluate(labels, path, password, profile string, strict bool) {
+	opts, err := readOptions(password, profile, strict)
+	if err != nil {
+		log.Fatalf("Error evaluating: %v", err)
+	}
+
+	pairs := [][2]string{{path, labels}}
+	if info, err := os.Stat(labels); err == nil && info.IsDir() {
+		if pairs, err = labelledDocuments(labels); err != nil {
+			log.Fatalf("Error evaluating: %v", err)
+		}
+	}
+
+	total := &wclist.Evaluation{}
+	for _, pair := range pairs {
+		evaluation, err := wclist.EvaluateFile(context.Background(), pair[0], pair[1], opts)
+		if err != nil {
+			log.Fatalf("Error evaluating: %v", err)
+		}
+		fmt.Printf("Evaluated %s: %d of %d rows matched, %d spurious\n",
+			pair[0], evaluation.Matched, evaluation.Expected, len(evaluation.Spurious))
+		total.Add(evaluation)
+	}
+
+	fmt.Println()
+	if err := total.WriteReport(os.Stdout); err != nil {
+		log.Fatalf("Error evaluating: %v", err)
+	}
+}
+
+// runGenerate writes a synthetic cause list and the labels of its items,
+// which -evaluate reads
+func runGenerate(out string, seed int64, matters int) {
+	var buf bytes.Buffer
+	items, err := wclist.GenerateCauseList(&buf, wclist.GenerateOptions{Seed: seed, Matters: matters})
+	if err != nil {
+		log.Fatalf("Error generating cause list: %v", err)
+	}
+	if err := os.WriteFile(out, buf.Bytes(), 0644); err != nil {
+		log.Fatalf("Error generating cause list: %v", err)
+	}
+
+	labels := strings.TrimSuffix(out, filepath.Ext(out)) + ".json"
+	file, err := os.Create(labels)
+	if err != nil {
+		log.Fatalf("Error generating cause list: %v", err)
+	}
+	defer file.Close()
+	if err := wclist.WriteItems(file, wclist.FormatJSON, items); err != nil {
+		log.Fatalf("Error generating cause list: %v", err)
+	}
+	fmt.Printf("Wrote %d items to %s and their labels to %s\n", len(items), out, labels)
+}
+
+// labelledDocuments returns the documents in a directory that have labels,
+// each paired with its labels file
+func labelledDocuments(dir string) ([][2]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	var pairs [][2]string
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || filepath.Ext(name) == ".json" {
+			continue
+		}
+		labels := filepath.Join(dir, strings.TrimSuffix(name, filepath.Ext(name))+".json")
+		if _, err := os.Stat(labels); err == nil {
+			pairs = append(pairs, [2]string{filepath.Join(dir, name), labels})
+		}
+	}
+	if len(pairs) == 0 {
+		return nil, fmt.Errorf("no labelled documents in %s", dir)
+	}
+	return pairs, nil
+}
+
+// readAssignedMatters reads a JSON file of assigned matters
+func readAssignedMatters(path string) []wclist.AssignedMatter {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		log.Fatalf("Error reading assigned matters: %v", err)
+	}
+	var assignedMatters []wclist.AssignedMatter
+	if err := json.Unmarshal(data, &assignedMatters); err != nil {
+		log.Fatalf("Error reading assigned matters: %v", err)
+	}
+	return assignedMatters
+}
 
+// runExample displays parsed items and searches for example assigned matters
+func runExample(causeList *wclist.CauseList) {
 	// Display parsed items
 	for i, item := range causeList.Items {
 		if i >= 5 { // Show only first 5 items for brevity
@@ -0,0 +1,90 @@
+package server
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+
+	"github.com/joshuamURD/wclist/wclist"
+
+	"github.com/labstack/echo/v4"
+)
+
+// handleExportItems downloads the items of the latest cause list of each
+// sitting matching the q parameter in the format given by the format
+// parameter (csv, json or xlsx)
+func (s *Server) handleExportItems(c echo.Context) error {
+	format, err := exportFormat(c)
+	if err != nil {
+		return err
+	}
+
+	var query wclist.Query
+	if text := c.QueryParam("q"); text != "" {
+		if query, err = wclist.ParseQuery(text); err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		}
+	}
+
+	var items []wclist.CauseListItem
+	for _, cl := range s.CauseLists() {
+		if query != nil {
+			items = append(items, cl.Query(query)...)
+		} else {
+			items = append(items, cl.Items...)
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := wclist.WriteItems(&buf, format, items); err != nil {
+		return err
+	}
+	return attachment(c, format, "cause_list", buf.Bytes())
+}
+
+// handleExportMatches searches the latest cause list of each sitting for the
+// assigned matters in the request body and downloads the matches in the
+// requested format
+func (s *Server) handleExportMatches(c echo.Context) error {
+	format, err := exportFormat(c)
+	if err != nil {
+		return err
+	}
+
+	var assignedMatters []wclist.AssignedMatter
+	if err := c.Bind(&assignedMatters); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid assigned matters")
+	}
+
+	var matches []wclist.MatchResult
+	for _, cl := range s.CauseLists() {
+		matches = append(matches, cl.SearchAssignedMatters(assignedMatters)...)
+	}
+
+	var buf bytes.Buffer
+	if err := wclist.WriteMatches(&buf, format, matches); err != nil {
+		return err
+	}
+	return attachment(c, format, "matches", buf.Bytes())
+}
+
+// exportFormat reads the format parameter, defaulting to CSV
+func exportFormat(c echo.Context) (wclist.ExportFormat, error) {
+	name := c.QueryParam("format")
+	if name == "" {
+		return wclist.FormatCSV, nil
+	}
+
+	format, err := wclist.ParseExportFormat(name)
+	if err != nil {
+		return "", echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	return format, nil
+}
+
+// attachment sends an export as a file download
+func attachment(c echo.Context, format wclist.ExportFormat, name string, data []byte) error {
+	c.Response().Header().Set(echo.HeaderContentDisposition,
+		fmt.Sprintf(`attachment; filename="%s.%s"`, name, format))
+	return c.Blob(http.StatusOK, format.ContentType(), data)
+}
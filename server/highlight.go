@@ -0,0 +1,50 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/joshuamURD/wclist/wclist"
+
+	"github.com/labstack/echo/v4"
+)
+
+// handleHighlightList searches a cause list for the assigned matters in the
+// request body and downloads a copy of the PDF it was read from, with the
+// matched rows highlighted and a cover page listing the matches. Lists that
+// weren't read from a PDF give 409 Conflict. Reading the PDF is abandoned
+// with 504 Gateway Timeout once Config.ParseTimeout has passed.
+func (s *Server) handleHighlightList(c echo.Context) error {
+	stored := s.storedList(c.Param("id"))
+	if stored == nil {
+		return echo.NewHTTPError(http.StatusNotFound, "cause list not found")
+	}
+	pdf, err := s.document(stored)
+	if err != nil {
+		return err
+	}
+	if pdf == nil || stored.List.Report.Format != "pdf" {
+		return echo.NewHTTPError(http.StatusConflict, "cause list was not read from a PDF")
+	}
+
+	var assignedMatters []wclist.AssignedMatter
+	if err := c.Bind(&assignedMatters); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid assigned matters")
+	}
+	matches := stored.List.SearchAssignedMatters(assignedMatters)
+
+	ctx, cancel := context.WithTimeout(c.Request().Context(), s.Config.ParseTimeout)
+	defer cancel()
+
+	var buf bytes.Buffer
+	document := bytes.NewReader(pdf)
+	if err := wclist.WriteHighlightedPDFContext(ctx, &buf, document, document.Size(), matches); err != nil {
+		return readError(err, s.Config.ParseTimeout)
+	}
+
+	c.Response().Header().Set(echo.HeaderContentDisposition,
+		fmt.Sprintf(`attachment; filename="cause_list_%s_highlighted.pdf"`, stored.ID))
+	return c.Blob(http.StatusOK, "application/pdf", buf.Bytes())
+}
@@ -0,0 +1,174 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/joshuamURD/wclist/wclist"
+)
+
+// indexFile is the name of the list index in Config.BlobDir
+const indexFile = "lists.json"
+
+// indexedList is the entry of a stored list in the list index, with the
+// reviews of its rows. Passwords aren't written to disk, so lists read from
+// encrypted PDFs can't be reprocessed once the server restarts.
+type indexedList struct {
+	ID            string             `json:"id"`
+	Blob          string             `json:"blob"`
+	Sitting       wclist.Sitting     `json:"sitting"`
+	Version       int                `json:"version"`
+	Profile       string             `json:"profile,omitempty"`
+	Strict        bool               `json:"strict,omitempty"`
+	MinConfidence float64            `json:"min_confidence,omitempty"`
+	List          *wclist.CauseList  `json:"list"`
+	Report        wclist.ParseReport `json:"report"`
+	Encrypted     bool               `json:"encrypted,omitempty"` // Read with a password
+	Reviews       []indexedReview    `json:"reviews,omitempty"`
+}
+
+// indexedReview is a review entry in the list index, with the row it was
+// queued for so that it's carried over when the list is read again
+type indexedReview struct {
+	reviewEntry
+	ItemIndex int               `json:"item_index"`
+	Source    wclist.Provenance `json:"source"`
+}
+
+// LoadLists loads the lists recorded in the list index of the blob store,
+// so that lists uploaded before the server restarted are held again along
+// with their reviews. It does nothing without a blob store or an index.
+func (s *Server) LoadLists() error {
+	if s.blobs == nil {
+		return nil
+	}
+	data, err := os.ReadFile(filepath.Join(s.Config.BlobDir, indexFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	var index []indexedList
+	if err := json.Unmarshal(data, &index); err != nil {
+		return fmt.Errorf("reading list index: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, entry := range index {
+		opts := wclist.ReadOptions{Strict: entry.Strict, MinConfidence: entry.MinConfidence}
+		if entry.Profile != "" {
+			if opts.Profile = wclist.ProfileByName(entry.Profile); opts.Profile == nil {
+				return fmt.Errorf("list %s was read with the unknown profile %q", entry.ID, entry.Profile)
+			}
+		}
+		entry.List.Report = entry.Report
+		stored := &storedList{
+			ID:      entry.ID,
+			List:    entry.List,
+			Sitting: entry.Sitting,
+			Version: entry.Version,
+			Blob:    entry.Blob,
+			options: opts,
+
+			passwordLost: entry.Encrypted,
+		}
+		s.lists = append(s.lists, stored)
+		if id, err := strconv.Atoi(entry.ID); err == nil {
+			s.nextID = max(s.nextID, id)
+		}
+
+		// Reviews are loaded before the list's rows are queued, so that
+		// reviewed rows aren't queued again
+		for _, review := range entry.Reviews {
+			loaded := review.reviewEntry
+			loaded.ListID, loaded.itemIndex, loaded.source = entry.ID, review.ItemIndex, review.Source
+			if loaded.Cells == nil {
+				loaded.Cells = map[string]string{}
+			}
+			s.reviews = append(s.reviews, &loaded)
+			if id, err := strconv.Atoi(loaded.ID); err == nil {
+				s.nextReviewID = max(s.nextReviewID, id)
+			}
+		}
+		s.queueReviews(stored)
+	}
+	for _, stored := range s.lists {
+		s.numberVersions(stored.sittingKey())
+	}
+	return nil
+}
+
+// saveLists writes the list index of the lists whose documents are in the
+// blob store. It is written under another name and renamed into place, so
+// that the index is never seen half written. The caller holds s.mu.
+func (s *Server) saveLists() {
+	if s.blobs == nil {
+		return
+	}
+	if err := s.writeIndex(); err != nil {
+		fmt.Printf("Failed to save the list index: %v\n", err)
+	}
+}
+
+// writeIndex writes the list index. The caller holds s.mu.
+func (s *Server) writeIndex() error {
+	index := []indexedList{}
+	for _, stored := range s.lists {
+		if stored.Blob == "" {
+			continue
+		}
+		entry := indexedList{
+			ID:            stored.ID,
+			Blob:          stored.Blob,
+			Sitting:       stored.Sitting,
+			Version:       stored.Version,
+			Strict:        stored.options.Strict,
+			MinConfidence: stored.options.MinConfidence,
+			List:          stored.List,
+			Report:        stored.List.Report,
+			Encrypted:     stored.options.Password != "" || stored.passwordLost,
+		}
+		if stored.options.Profile != nil {
+			entry.Profile = stored.options.Profile.Name
+		}
+		for _, review := range s.reviews {
+			if review.ListID == stored.ID {
+				entry.Reviews = append(entry.Reviews, indexedReview{reviewEntry: *review, ItemIndex: review.itemIndex, Source: review.source})
+			}
+		}
+		index = append(index, entry)
+	}
+	data, err := json.Marshal(index)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(s.Config.BlobDir, 0o755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(s.Config.BlobDir, indexFile+".tmp*")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := os.Rename(tmp.Name(), filepath.Join(s.Config.BlobDir, indexFile)); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return nil
+}
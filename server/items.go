@@ -0,0 +1,120 @@
+package server
+
+import (
+	"net/http"
+	"slices"
+	"strconv"
+
+	"github.com/joshuamURD/wclist/wclist"
+
+	"github.com/labstack/echo/v4"
+)
+
+// handleLists returns every cause list held by the server, in the
+// versioned CauseList JSON encoding, with the sitting each is a version of.
+// Lists replaced by a later version have current set to false.
+func (s *Server) handleLists(c echo.Context) error {
+	current := s.currentLists()
+	lists := []map[string]interface{}{}
+	for _, stored := range s.storedLists() {
+		lists = append(lists, map[string]interface{}{
+			"id":      stored.ID,
+			"sitting": stored.sittingKey(),
+			"version": stored.Version,
+			"current": slices.Contains(current, stored),
+			"list":    stored.List,
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"lists": lists,
+	})
+}
+
+// handleItems returns the items across the latest version of each
+// sitting's cause list matching the q parameter. Without a query every item
+// is returned.
+func (s *Server) handleItems(c echo.Context) error {
+	var query wclist.Query
+	if text := c.QueryParam("q"); text != "" {
+		q, err := wclist.ParseQuery(text)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		}
+		query = q
+	}
+
+	items := []map[string]interface{}{}
+	for _, stored := range s.currentLists() {
+		matched := stored.List.Items
+		if query != nil {
+			matched = stored.List.Query(query)
+		}
+		for _, item := range matched {
+			items = append(items, itemResponse(stored, item))
+		}
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"count": len(items),
+		"items": items,
+	})
+}
+
+// itemResponse describes an item, where it was read from and the cause list
+// it was published in
+func itemResponse(stored *storedList, item wclist.CauseListItem) map[string]interface{} {
+	fields := make(map[string]string, len(wclist.ItemFields))
+	for _, field := range wclist.ItemFields {
+		fields[field.Name] = field.Value(item)
+	}
+
+	return map[string]interface{}{
+		"list_id":      stored.ID,
+		"sitting":      stored.sittingKey(),
+		"version":      stored.Version,
+		"jurisdiction": stored.List.Jurisdiction,
+		"warden":       stored.List.Warden,
+		"release_date": stored.List.ReleaseDate,
+		"item":         fields,
+		"parties":      wclist.ItemParties(item),
+		"validation":   wclist.ValidateItem(item),
+		"source":       wclist.ItemSource(item),
+	}
+}
+
+// handleItemSource renders the source text of the item with the given
+// matter number in a cause list, so that a match can be checked against the
+// original document. A matter can be listed more than once, so the row is
+// named by the page, table and line parameters, as in the item's source.
+func (s *Server) handleItemSource(c echo.Context) error {
+	stored := s.storedList(c.Param("id"))
+	if stored == nil {
+		return echo.NewHTTPError(http.StatusNotFound, "cause list not found")
+	}
+	matter, err := strconv.ParseUint(c.Param("matter"), 10, 64)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid matter number")
+	}
+	var row wclist.Provenance
+	for name, field := range map[string]*int{"page": &row.Page, "table": &row.Table, "line": &row.FirstLine} {
+		text := c.QueryParam(name)
+		if text == "" {
+			continue
+		}
+		if *field, err = strconv.Atoi(text); err != nil || *field < 0 {
+			return echo.NewHTTPError(http.StatusBadRequest, "invalid "+name)
+		}
+	}
+	if row.FirstLine == 0 {
+		return echo.NewHTTPError(http.StatusBadRequest, "line is required")
+	}
+
+	for _, item := range stored.List.Items {
+		source := wclist.ItemSource(item)
+		if item.GetMatterNumber() == matter && source.SameRow(row) {
+			return c.String(http.StatusOK, source.String())
+		}
+	}
+	return echo.NewHTTPError(http.StatusNotFound, "item not found")
+}
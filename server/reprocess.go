@@ -0,0 +1,236 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/joshuamURD/wclist/wclist"
+
+	"github.com/labstack/echo/v4"
+)
+
+// Reprocess job statuses
+const (
+	jobRunning = "running"
+	jobDone    = "done"
+)
+
+// reprocessJob reads stored cause lists again from their documents with the
+// current parser and records how their items changed
+type reprocessJob struct {
+	ID            string            `json:"id"`
+	Status        string            `json:"status"`
+	ParserVersion int               `json:"parser_version"`
+	Started       time.Time         `json:"started"`
+	Finished      *time.Time        `json:"finished,omitempty"`
+	Lists         []reprocessedList `json:"lists"`
+	Skipped       []string          `json:"skipped"` // Lists already read by the current parser
+}
+
+// reprocessedList is the outcome of reading one cause list again
+type reprocessedList struct {
+	ListID      string              `json:"list_id"`
+	FromVersion int                 `json:"from_version"`
+	Items       int                 `json:"items"`
+	Changes     []wclist.ItemChange `json:"changes"`
+	Error       string              `json:"error,omitempty"`
+}
+
+// clone copies the job so that it can be encoded outside the lock
+func (j *reprocessJob) clone() *reprocessJob {
+	c := *j
+	c.Lists = slices.Clone(j.Lists)
+	c.Skipped = slices.Clone(j.Skipped)
+	return &c
+}
+
+// handleReprocess starts a job that reads every stored cause list read by
+// an older parser again from its document, or every list when the all
+// parameter is true, and responds with 202 Accepted and the job. The job's
+// progress and the items that changed are at GET /api/v1/reprocess/{id}.
+// Lists read from encrypted PDFs can't be read again once the server has
+// restarted, as their passwords aren't kept; the job records an error for
+// each of them.
+func (s *Server) handleReprocess(c echo.Context) error {
+	all := false
+	if value := c.QueryParam("all"); value != "" {
+		var err error
+		if all, err = strconv.ParseBool(value); err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, "all must be true or false")
+		}
+	}
+
+	s.mu.Lock()
+	s.nextJobID++
+	job := &reprocessJob{
+		ID:            strconv.Itoa(s.nextJobID),
+		Status:        jobRunning,
+		ParserVersion: wclist.ParserVersion,
+		Started:       time.Now(),
+		Lists:         []reprocessedList{},
+		Skipped:       []string{},
+	}
+	s.jobs = append(s.jobs, job)
+	response := job.clone()
+	s.mu.Unlock()
+
+	go s.runReprocess(job, all)
+	return c.JSON(http.StatusAccepted, response)
+}
+
+// handleReprocessJob returns a reprocess job
+func (s *Server) handleReprocessJob(c echo.Context) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, job := range s.jobs {
+		if job.ID == c.Param("id") {
+			return c.JSON(http.StatusOK, job.clone())
+		}
+	}
+	return echo.NewHTTPError(http.StatusNotFound, "reprocess job not found")
+}
+
+// runReprocess reads the lists of a job again one at a time, replacing
+// each with its new reading as it finishes
+func (s *Server) runReprocess(job *reprocessJob, all bool) {
+	for _, stored := range s.storedLists() {
+		if !all && stored.List.ParserVersion >= wclist.ParserVersion {
+			s.mu.Lock()
+			job.Skipped = append(job.Skipped, stored.ID)
+			s.mu.Unlock()
+			continue
+		}
+
+		result := s.reprocessList(stored)
+		s.mu.Lock()
+		job.Lists = append(job.Lists, result)
+		s.mu.Unlock()
+	}
+
+	s.mu.Lock()
+	finished := time.Now()
+	job.Status, job.Finished = jobDone, &finished
+	s.mu.Unlock()
+}
+
+// errPasswordLost is the reprocessing error of lists from encrypted PDFs
+// loaded from the list index, whose password isn't kept
+const errPasswordLost = "the list was read from an encrypted PDF and its password isn't kept once the server restarts; upload the PDF again with its password to read it with the current parser"
+
+// reprocessList reads a stored list again from its document with the
+// options it was first read with and the server's current limits. Lists
+// read from encrypted PDFs before the server restarted are skipped with
+// errPasswordLost.
+func (s *Server) reprocessList(stored *storedList) reprocessedList {
+	result := reprocessedList{ListID: stored.ID, FromVersion: stored.List.ParserVersion, Changes: []wclist.ItemChange{}}
+	if stored.passwordLost {
+		result.Error = errPasswordLost
+		return result
+	}
+
+	document, err := s.document(stored)
+	if err == nil && document == nil {
+		result.Error = "the document the list was read from wasn't kept"
+		return result
+	}
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+
+	opts := stored.options
+	opts.MaxBytes = s.Config.MaxUploadBytes
+	opts.MaxPages = s.Config.MaxPages
+	opts.PageTimeout = s.Config.PageTimeout
+	opts.Cache = s.cache
+	ctx, cancel := context.WithTimeout(context.Background(), s.Config.ParseTimeout)
+	defer cancel()
+
+	cl := wclist.NewCauseList(stored.List.Jurisdiction, stored.List.Warden, stored.List.ReleaseDate)
+	cl.Location, cl.SittingDate = stored.List.Location, stored.List.SittingDate
+	if err := cl.ReadCauseListOptions(ctx, bytes.NewReader(document), int64(len(document)), opts); err != nil {
+		result.Error = err.Error()
+		// Indexes written before encrypted lists were recorded don't say
+		// whether a password was lost
+		if errors.Is(err, wclist.ErrEncryptedPDF) && opts.Password == "" {
+			result.Error = errPasswordLost
+		}
+		return result
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	changes, ok := s.replaceList(stored.ID, cl)
+	if !ok {
+		result.Error = "cause list not found"
+		return result
+	}
+	result.Items = len(cl.Items)
+	if changes != nil {
+		result.Changes = changes
+	}
+	return result
+}
+
+// replaceList replaces a stored list with a new reading of its document and
+// returns how the items changed. Rows a reviewer accepted are merged into
+// the new reading again, so only the parser's changes are reported.
+// Reviews of rows whose text is unchanged are carried over to the new
+// reading; pending reviews of rows that changed or are gone are superseded,
+// as are all of them when the list isn't the latest version of its sitting.
+// The caller holds s.mu.
+func (s *Server) replaceList(id string, cl *wclist.CauseList) ([]wclist.ItemChange, bool) {
+	i := slices.IndexFunc(s.lists, func(stored *storedList) bool { return stored.ID == id })
+	if i < 0 {
+		return nil, false
+	}
+	old := s.lists[i]
+
+	var pending []*reviewEntry
+	for _, entry := range s.reviews {
+		if entry.ListID != id {
+			continue
+		}
+		switch entry.Status {
+		case reviewPending:
+			pending = append(pending, entry)
+		case reviewAccepted:
+			if item, err := entry.draft(); err == nil {
+				cl.Items = mergeReviewedRow(cl.Items, entry, item)
+			}
+		}
+	}
+
+	changes := wclist.CompareItems(old.List.Items, cl.Items)
+	s.lists[i] = old.withList(cl)
+	carried := s.queueReviews(s.lists[i])
+	for _, entry := range pending {
+		if !carried[entry] {
+			entry.Status = reviewSuperseded
+		}
+	}
+	s.numberVersions(old.sittingKey())
+	s.saveLists()
+	return changes, true
+}
+
+// mergeReviewedRow puts an accepted row into a new reading of its list, in
+// place of the item for the same matter read from the same row, or from a
+// row with the same text if it moved, or after the other items
+func mergeReviewedRow(items []wclist.CauseListItem, entry *reviewEntry, item wclist.CauseListItem) []wclist.CauseListItem {
+	for i, read := range items {
+		source := wclist.ItemSource(read)
+		if wclist.ItemType(read) == entry.Section && read.GetMatterNumber() == item.GetMatterNumber() &&
+			(source.SameRow(entry.source) || source.Raw == entry.source.Raw) {
+			items[i] = item
+			return items
+		}
+	}
+	return append(items, item)
+}
@@ -0,0 +1,359 @@
+package server
+
+import (
+	"maps"
+	"net/http"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/joshuamURD/wclist/wclist"
+
+	"github.com/labstack/echo/v4"
+)
+
+// Why a row is waiting for review
+const (
+	reviewUnparsed      = "unparsed"       // The row couldn't be parsed, or strict mode rejected it
+	reviewLowConfidence = "low_confidence" // The row was parsed but its item scored below Config.ReviewConfidence
+)
+
+// Review statuses
+const (
+	reviewPending   = "pending"
+	reviewAccepted  = "accepted"
+	reviewDismissed = "dismissed"
+
+	// reviewSuperseded entries were pending when their list was reprocessed,
+	// which queued the rows of the new reading in their place, or when an
+	// amended list for the same sitting was added
+	reviewSuperseded = "superseded"
+)
+
+// reviewEntry is a row held for a reviewer to correct and accept or dismiss.
+// Accepted rows are merged into their cause list: unparsed rows are added
+// to it and low-confidence items are replaced.
+type reviewEntry struct {
+	ID      string `json:"id"`
+	ListID  string `json:"list_id"`
+	Reason  string `json:"reason"`
+	Status  string `json:"status"`
+	Page    int    `json:"page,omitempty"`
+	Table   int    `json:"table,omitempty"`
+	Line    int    `json:"line"`
+	Raw     string `json:"raw"`
+	Problem string `json:"problem"` // Why the row was rejected, or its validation warnings
+
+	// Section and Cells are the reviewer's draft of the item, named by the
+	// columns of the section's matter type
+	Section string            `json:"section"`
+	Cells   map[string]string `json:"cells"`
+
+	// Validation checks the item the draft builds, or DraftError says why
+	// it can't be built
+	Validation *wclist.Validation `json:"validation,omitempty"`
+	DraftError string             `json:"draft_error,omitempty"`
+
+	itemIndex int // Position of a low-confidence item in its list, or -1
+	source    wclist.Provenance
+}
+
+// draft builds the item described by the entry's section and cells
+func (e *reviewEntry) draft() (wclist.CauseListItem, error) {
+	return wclist.ItemFromCells(e.Section, e.Cells, e.source)
+}
+
+// revalidate checks the draft after it changes
+func (e *reviewEntry) revalidate() {
+	e.Validation, e.DraftError = nil, ""
+	item, err := e.draft()
+	if err != nil {
+		e.DraftError = err.Error()
+		return
+	}
+	validation := wclist.ValidateItem(item)
+	e.Validation = &validation
+}
+
+// clone copies the entry so that it can be encoded outside the lock
+func (e *reviewEntry) clone() *reviewEntry {
+	c := *e
+	c.Cells = maps.Clone(e.Cells)
+	return &c
+}
+
+// leadingNumberPattern matches the matter number at the start of a row
+var leadingNumberPattern = regexp.MustCompile(`^\s*(\d+)\b`)
+
+// queueReviews holds the rows of a list that couldn't be parsed or whose
+// items scored below Config.ReviewConfidence. Rows the list already has a
+// review for, accepted, dismissed or pending, are carried over unless their
+// text changed, so reading a list again only queues rows that are new or
+// changed. It returns the reviews carried over. The caller holds s.mu.
+func (s *Server) queueReviews(stored *storedList) map[*reviewEntry]bool {
+	carried := make(map[*reviewEntry]bool)
+	queue := func(entry *reviewEntry) {
+		entry.ListID = stored.ID
+		if earlier := s.earlierReview(entry, carried); earlier != nil {
+			// Pending reviews move to the row of the new reading
+			if earlier.Status == reviewPending {
+				earlier.Page, earlier.Table, earlier.Line = entry.Page, entry.Table, entry.Line
+				earlier.itemIndex, earlier.source = entry.itemIndex, entry.source
+			}
+			carried[earlier] = true
+			return
+		}
+		s.nextReviewID++
+		entry.ID = strconv.Itoa(s.nextReviewID)
+		entry.Status = reviewPending
+		entry.revalidate()
+		s.reviews = append(s.reviews, entry)
+	}
+
+	for _, rowErr := range stored.List.Report.RowErrors {
+		cells := map[string]string{}
+		if match := leadingNumberPattern.FindStringSubmatch(rowErr.Raw); match != nil {
+			cells["matter_number"] = match[1]
+		}
+		queue(&reviewEntry{
+			Reason:    reviewUnparsed,
+			Page:      rowErr.Page,
+			Table:     rowErr.Table,
+			Line:      rowErr.Line,
+			Raw:       rowErr.Raw,
+			Problem:   rowErr.Reason,
+			Section:   rowErr.Section,
+			Cells:     cells,
+			itemIndex: -1,
+			source:    wclist.Provenance{Page: rowErr.Page, Table: rowErr.Table, FirstLine: rowErr.Line, LastLine: rowErr.Line, Raw: rowErr.Raw},
+		})
+	}
+
+	for i, item := range stored.List.Items {
+		validation := wclist.ValidateItem(item)
+		source := wclist.ItemSource(item)
+		if validation.Confidence >= s.Config.ReviewConfidence {
+			continue
+		}
+		var problems []string
+		for _, warning := range validation.Warnings {
+			problems = append(problems, warning.String())
+		}
+		queue(&reviewEntry{
+			Reason:    reviewLowConfidence,
+			Page:      source.Page,
+			Table:     source.Table,
+			Line:      source.FirstLine,
+			Raw:       source.Raw,
+			Problem:   strings.Join(problems, "; "),
+			Section:   wclist.ItemType(item),
+			Cells:     wclist.ItemCells(item),
+			itemIndex: i,
+			source:    source,
+		})
+	}
+	return carried
+}
+
+// earlierReview returns the review of entry's list for the same row as
+// entry with the same text, or for a row with the same text elsewhere when
+// the row moved. Superseded reviews don't count, and a pending review is
+// carried over to one row only. The caller holds s.mu.
+func (s *Server) earlierReview(entry *reviewEntry, carried map[*reviewEntry]bool) *reviewEntry {
+	var moved *reviewEntry
+	for _, earlier := range s.reviews {
+		if earlier.ListID != entry.ListID || earlier.Status == reviewSuperseded || earlier.Raw != entry.Raw ||
+			(earlier.Status == reviewPending && carried[earlier]) {
+			continue
+		}
+		if earlier.source.SameRow(entry.source) {
+			return earlier
+		}
+		if moved == nil {
+			moved = earlier
+		}
+	}
+	return moved
+}
+
+// reviewCorrection is a reviewer's change to the draft of a review entry.
+// Cells are merged into the draft; an empty value clears a cell.
+type reviewCorrection struct {
+	Section string            `json:"section"`
+	Cells   map[string]string `json:"cells"`
+}
+
+// handleReviewQueue lists the review entries with the status given by the
+// status parameter (pending by default, or "all"), optionally only those
+// of the cause list given by the list_id parameter
+func (s *Server) handleReviewQueue(c echo.Context) error {
+	status := c.QueryParam("status")
+	if status == "" {
+		status = reviewPending
+	}
+	listID := c.QueryParam("list_id")
+
+	s.mu.RLock()
+	entries := []*reviewEntry{}
+	for _, entry := range s.reviews {
+		if (status == "all" || entry.Status == status) && (listID == "" || entry.ListID == listID) {
+			entries = append(entries, entry.clone())
+		}
+	}
+	s.mu.RUnlock()
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"count":   len(entries),
+		"entries": entries,
+	})
+}
+
+// handleReviewEntry returns one review entry
+func (s *Server) handleReviewEntry(c echo.Context) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	entry := s.reviewEntry(c.Param("id"))
+	if entry == nil {
+		return echo.NewHTTPError(http.StatusNotFound, "review entry not found")
+	}
+	return c.JSON(http.StatusOK, entry.clone())
+}
+
+// handleCorrectReview applies a correction to the draft of a pending
+// review entry and returns the entry with the draft validated
+func (s *Server) handleCorrectReview(c echo.Context) error {
+	var correction reviewCorrection
+	if err := c.Bind(&correction); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid correction")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entry, err := s.pendingReviewEntry(c.Param("id"))
+	if err != nil {
+		return err
+	}
+	entry.correct(correction)
+	s.saveLists()
+	return c.JSON(http.StatusOK, entry.clone())
+}
+
+// handleAcceptReview applies an optional correction to a pending review
+// entry and merges the item its draft builds into the cause list. Drafts
+// that don't build an item give 422 Unprocessable Entity.
+func (s *Server) handleAcceptReview(c echo.Context) error {
+	var correction reviewCorrection
+	if c.Request().ContentLength != 0 {
+		if err := c.Bind(&correction); err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, "invalid correction")
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entry, err := s.pendingReviewEntry(c.Param("id"))
+	if err != nil {
+		return err
+	}
+	entry.correct(correction)
+	item, err := entry.draft()
+	if err != nil {
+		return echo.NewHTTPError(http.StatusUnprocessableEntity, err.Error())
+	}
+
+	stored := s.mergeReviewedItem(entry, item)
+	if stored == nil {
+		return echo.NewHTTPError(http.StatusNotFound, "cause list not found")
+	}
+	entry.Status = reviewAccepted
+	s.saveLists()
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"entry": entry.clone(),
+		"item":  itemResponse(stored, item),
+	})
+}
+
+// handleDismissReview leaves a pending row out of its cause list
+func (s *Server) handleDismissReview(c echo.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entry, err := s.pendingReviewEntry(c.Param("id"))
+	if err != nil {
+		return err
+	}
+	entry.Status = reviewDismissed
+	s.saveLists()
+	return c.JSON(http.StatusOK, entry.clone())
+}
+
+// correct merges a correction into the draft
+func (e *reviewEntry) correct(correction reviewCorrection) {
+	if correction.Section != "" {
+		e.Section = correction.Section
+	}
+	for column, value := range correction.Cells {
+		if value == "" {
+			delete(e.Cells, column)
+		} else {
+			e.Cells[column] = value
+		}
+	}
+	e.revalidate()
+}
+
+// reviewEntry returns the review entry with the given ID, or nil. The
+// caller holds s.mu.
+func (s *Server) reviewEntry(id string) *reviewEntry {
+	for _, entry := range s.reviews {
+		if entry.ID == id {
+			return entry
+		}
+	}
+	return nil
+}
+
+// pendingReviewEntry returns the review entry with the given ID, or an HTTP
+// error if there is none or it was already reviewed. The caller holds s.mu.
+func (s *Server) pendingReviewEntry(id string) (*reviewEntry, error) {
+	entry := s.reviewEntry(id)
+	if entry == nil {
+		return nil, echo.NewHTTPError(http.StatusNotFound, "review entry not found")
+	}
+	if entry.Status != reviewPending {
+		return nil, echo.NewHTTPError(http.StatusConflict, "review entry is already "+entry.Status)
+	}
+	return entry, nil
+}
+
+// mergeReviewedItem adds an accepted item to the entry's cause list, in
+// place of the low-confidence item it corrects. The list is replaced by an
+// updated copy, so requests already reading the old list aren't affected,
+// and later searches see the item. The caller holds s.mu and saves the
+// lists once the entry is marked accepted.
+func (s *Server) mergeReviewedItem(entry *reviewEntry, item wclist.CauseListItem) *storedList {
+	i := slices.IndexFunc(s.lists, func(stored *storedList) bool { return stored.ID == entry.ListID })
+	if i < 0 {
+		return nil
+	}
+	old := s.lists[i]
+
+	items := slices.Clone(old.List.Items)
+	if entry.itemIndex >= 0 && entry.itemIndex < len(items) {
+		items[entry.itemIndex] = item
+	} else {
+		items = append(items, item)
+	}
+
+	cl := wclist.NewCauseList(old.List.Jurisdiction, old.List.Warden, old.List.ReleaseDate)
+	cl.Location, cl.SittingDate = old.List.Location, old.List.SittingDate
+	cl.Items = items
+	cl.Report = old.List.Report
+	cl.ParserVersion = old.List.ParserVersion
+	s.lists[i] = old.withList(cl)
+	return s.lists[i]
+}
@@ -0,0 +1,242 @@
+package server
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/joshuamURD/wclist/wclist"
+)
+
+// reviewQueue is the response of GET /api/v1/review
+type reviewQueue struct {
+	Count   int           `json:"count"`
+	Entries []reviewEntry `json:"entries"`
+}
+
+// itemList is the response of GET /api/v1/items
+type itemList struct {
+	Count int `json:"count"`
+	Items []struct {
+		Item map[string]string `json:"item"`
+	} `json:"items"`
+}
+
+// objector returns the objector of the item with the given matter number,
+// and whether there is one
+func (l itemList) objector(matter string) (string, bool) {
+	for _, item := range l.Items {
+		if item.Item["matter"] == matter {
+			return item.Item["objector"], true
+		}
+	}
+	return "", false
+}
+
+func TestReview(t *testing.T) {
+	released := time.Date(2025, time.June, 20, 0, 0, 0, 0, time.UTC)
+	parsed := func(t *testing.T) wclist.CauseListItem {
+		return testItem(t, 10, "1 698561 KARORA (HIGGINSVILLE) PTY LTD E 15/2082 FMG RESOURCES PTY LTD", map[string]string{
+			"matter_number": "1", "objection_number": "698561", "objector": "KARORA (HIGGINSVILLE) PTY LTD",
+			"tenement": "E 15/2082", "applicant": "FMG RESOURCES PTY LTD",
+		})
+	}
+	unparsed := wclist.RowParseError{Page: 2, Line: 20, Section: "objection", Raw: "2 ACME PTY LTD E 15/2100 FMG RESOURCES PTY LTD", Reason: "missing objection number"}
+	unreadable := wclist.RowParseError{Page: 2, Line: 30, Section: "objection", Raw: "3 BEACON E 15/2101", Reason: "missing objection number"}
+	accepted := `{"cells": {"objection_number": "714469", "objector": "ACME PTY LTD", "tenement": "E 15/2100", "applicant": "FMG RESOURCES PTY LTD"}}`
+
+	pending := func(t *testing.T, s *Server) []reviewEntry {
+		t.Helper()
+		var queue reviewQueue
+		request(t, s, http.MethodGet, "/api/v1/review", "", http.StatusOK, &queue)
+		return queue.Entries
+	}
+	entry := func(t *testing.T, s *Server, id string) reviewEntry {
+		t.Helper()
+		var entry reviewEntry
+		request(t, s, http.MethodGet, "/api/v1/review/"+id, "", http.StatusOK, &entry)
+		return entry
+	}
+	items := func(t *testing.T, s *Server) itemList {
+		t.Helper()
+		var items itemList
+		request(t, s, http.MethodGet, "/api/v1/items", "", http.StatusOK, &items)
+		return items
+	}
+	reprocess := func(t *testing.T, s *Server, id string, cl *wclist.CauseList) []wclist.ItemChange {
+		t.Helper()
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		changes, ok := s.replaceList(id, cl)
+		if !ok {
+			t.Fatalf("Expected list %s to be replaced", id)
+		}
+		return changes
+	}
+
+	t.Run("Queued rows", func(t *testing.T) {
+		s := newTestServer(t, "")
+		s.AddCauseList(testList(released, []wclist.CauseListItem{parsed(t)}, unparsed))
+
+		entries := pending(t, s)
+		if len(entries) != 1 || entries[0].Reason != reviewUnparsed || entries[0].Raw != unparsed.Raw ||
+			entries[0].Cells["matter_number"] != "2" {
+			t.Fatalf("Expected the unparsed row to be queued with its matter number, got %+v", entries)
+		}
+	})
+
+	t.Run("Accepting merges the item", func(t *testing.T) {
+		s := newTestServer(t, "")
+		s.AddCauseList(testList(released, []wclist.CauseListItem{parsed(t)}, unparsed))
+		id := pending(t, s)[0].ID
+
+		var response struct {
+			Entry reviewEntry `json:"entry"`
+		}
+		request(t, s, http.MethodPost, "/api/v1/review/"+id+"/accept", accepted, http.StatusOK, &response)
+		if response.Entry.Status != reviewAccepted {
+			t.Fatalf("Expected the entry to be accepted, got %q", response.Entry.Status)
+		}
+		list := items(t, s)
+		if objector, ok := list.objector("2"); list.Count != 2 || !ok || objector != "ACME PTY LTD" {
+			t.Fatalf("Expected the accepted row to be added to the list, got %+v", list.Items)
+		}
+		if entries := pending(t, s); len(entries) != 0 {
+			t.Fatalf("Expected no pending reviews, got %+v", entries)
+		}
+		request(t, s, http.MethodPost, "/api/v1/review/"+id+"/accept", "", http.StatusConflict, nil)
+		if list := items(t, s); list.Count != 2 {
+			t.Fatalf("Expected accepting again to leave the list alone, got %d items", list.Count)
+		}
+	})
+
+	t.Run("Corrections are revalidated", func(t *testing.T) {
+		s := newTestServer(t, "")
+		s.AddCauseList(testList(released, []wclist.CauseListItem{parsed(t)}, unparsed))
+		id := pending(t, s)[0].ID
+
+		var corrected reviewEntry
+		request(t, s, http.MethodPatch, "/api/v1/review/"+id, `{"cells": {"matter_number": "two"}}`, http.StatusOK, &corrected)
+		if corrected.DraftError == "" || corrected.Validation != nil {
+			t.Fatalf("Expected a draft without a matter number to fail, got %+v", corrected)
+		}
+
+		var fixed reviewEntry
+		request(t, s, http.MethodPatch, "/api/v1/review/"+id, `{"cells": {"matter_number": "2", "objector": "ACME PTY LTD", "tenement": "E 15/2100"}}`, http.StatusOK, &fixed)
+		if fixed.DraftError != "" || fixed.Validation == nil {
+			t.Fatalf("Expected the corrected draft to be validated, got %+v", fixed)
+		}
+		if got := entry(t, s, id); got.Cells["objector"] != "ACME PTY LTD" || got.Validation == nil || got.Status != reviewPending {
+			t.Fatalf("Expected the correction to be kept, got %+v", got)
+		}
+
+		request(t, s, http.MethodPost, "/api/v1/review/"+id+"/accept", `{"cells": {"matter_number": ""}}`, http.StatusUnprocessableEntity, nil)
+		if got := entry(t, s, id); got.Status != reviewPending || got.DraftError == "" {
+			t.Fatalf("Expected a draft that doesn't build an item to stay pending, got %+v", got)
+		}
+		if list := items(t, s); list.Count != 1 {
+			t.Fatalf("Expected the list to be unchanged, got %d items", list.Count)
+		}
+	})
+
+	t.Run("Dismissed rows stay out", func(t *testing.T) {
+		s := newTestServer(t, "")
+		id := s.AddCauseList(testList(released, []wclist.CauseListItem{parsed(t)}, unparsed))
+		review := pending(t, s)[0].ID
+
+		request(t, s, http.MethodPost, "/api/v1/review/"+review+"/dismiss", "", http.StatusOK, nil)
+		request(t, s, http.MethodPost, "/api/v1/review/"+review+"/accept", accepted, http.StatusConflict, nil)
+		if list := items(t, s); list.Count != 1 {
+			t.Fatalf("Expected the dismissed row to stay out of the list, got %d items", list.Count)
+		}
+
+		reprocess(t, s, id, testList(released, []wclist.CauseListItem{parsed(t)}, unparsed))
+		if entries := pending(t, s); len(entries) != 0 {
+			t.Fatalf("Expected the dismissed row not to be queued again, got %+v", entries)
+		}
+		if got := entry(t, s, review); got.Status != reviewDismissed {
+			t.Fatalf("Expected the row to stay dismissed, got %q", got.Status)
+		}
+		if list := items(t, s); list.Count != 1 {
+			t.Fatalf("Expected the dismissed row to stay out of the new reading, got %d items", list.Count)
+		}
+	})
+
+	t.Run("Reprocessing carries reviews over", func(t *testing.T) {
+		s := newTestServer(t, "")
+		id := s.AddCauseList(testList(released, []wclist.CauseListItem{parsed(t)}, unparsed, unreadable))
+		entries := pending(t, s)
+		request(t, s, http.MethodPost, "/api/v1/review/"+entries[0].ID+"/accept", accepted, http.StatusOK, nil)
+
+		// The accepted row moved down a line, and the pending row changed
+		moved, changed := unparsed, unreadable
+		moved.Line++
+		changed.Raw = "3 BEACON MINERALS E 15/2101"
+		changes := reprocess(t, s, id, testList(released, []wclist.CauseListItem{parsed(t)}, moved, changed))
+		if len(changes) != 0 {
+			t.Fatalf("Expected the accepted row not to be reported as a change, got %+v", changes)
+		}
+		list := items(t, s)
+		if objector, _ := list.objector("2"); list.Count != 2 || objector != "ACME PTY LTD" {
+			t.Fatalf("Expected the accepted row to be merged into the new reading, got %+v", list.Items)
+		}
+		if got := entry(t, s, entries[0].ID); got.Status != reviewAccepted {
+			t.Fatalf("Expected the accepted review to be carried over, got %q", got.Status)
+		}
+		if got := entry(t, s, entries[1].ID); got.Status != reviewSuperseded {
+			t.Fatalf("Expected the review of the changed row to be superseded, got %q", got.Status)
+		}
+		if queued := pending(t, s); len(queued) != 1 || queued[0].Raw != changed.Raw {
+			t.Fatalf("Expected only the changed row to be queued, got %+v", queued)
+		}
+	})
+
+	t.Run("Accepted rows replace their new reading", func(t *testing.T) {
+		s := newTestServer(t, "")
+		id := s.AddCauseList(testList(released, []wclist.CauseListItem{parsed(t)}, unparsed))
+		request(t, s, http.MethodPost, "/api/v1/review/"+pending(t, s)[0].ID+"/accept", accepted, http.StatusOK, nil)
+
+		// A new parser reads the row, but not as the reviewer did
+		read := testItem(t, unparsed.Line, unparsed.Raw, map[string]string{"matter_number": "2", "objector": "ACME", "tenement": "E 15/2100"})
+		reprocess(t, s, id, testList(released, []wclist.CauseListItem{parsed(t), read}))
+		list := items(t, s)
+		if objector, _ := list.objector("2"); list.Count != 2 || objector != "ACME PTY LTD" {
+			t.Fatalf("Expected the accepted row to replace the item read from it, got %+v", list.Items)
+		}
+	})
+
+	t.Run("Reviews are kept across restarts", func(t *testing.T) {
+		dir := t.TempDir()
+		s := newTestServer(t, dir)
+		s.AddCauseListDocument(testList(released, []wclist.CauseListItem{parsed(t)}, unparsed, unreadable), []byte("document"))
+		entries := pending(t, s)
+		request(t, s, http.MethodPost, "/api/v1/review/"+entries[0].ID+"/accept", accepted, http.StatusOK, nil)
+		request(t, s, http.MethodPost, "/api/v1/review/"+entries[1].ID+"/dismiss", "", http.StatusOK, nil)
+
+		restarted := newTestServer(t, dir)
+		if queued := pending(t, restarted); len(queued) != 0 {
+			t.Fatalf("Expected reviewed rows not to be queued again, got %+v", queued)
+		}
+		if got := entry(t, restarted, entries[0].ID); got.Status != reviewAccepted || got.Cells["objector"] != "ACME PTY LTD" {
+			t.Fatalf("Expected the accepted review to be loaded, got %+v", got)
+		}
+		if got := entry(t, restarted, entries[1].ID); got.Status != reviewDismissed {
+			t.Fatalf("Expected the dismissed review to be loaded, got %q", got.Status)
+		}
+		request(t, restarted, http.MethodPost, "/api/v1/review/"+entries[0].ID+"/accept", accepted, http.StatusConflict, nil)
+		if list := items(t, restarted); list.Count != 2 {
+			t.Fatalf("Expected the accepted row once, got %d items", list.Count)
+		}
+	})
+
+	t.Run("Encrypted lists after a restart", func(t *testing.T) {
+		dir := t.TempDir()
+		s := newTestServer(t, dir)
+		id := s.addList(testList(released, []wclist.CauseListItem{parsed(t)}), []byte("document"), wclist.ReadOptions{Password: "secret"})
+
+		restarted := newTestServer(t, dir)
+		if result := restarted.reprocessList(restarted.storedList(id)); result.Error != errPasswordLost {
+			t.Fatalf("Expected the list not to be read again without its password, got %+v", result)
+		}
+	})
+}
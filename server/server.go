@@ -3,8 +3,11 @@ package server
 import (
 	"fmt"
 	"net/http"
+	"sync"
+	"time"
 
 	"github.com/joshuamURD/wclist/config"
+	"github.com/joshuamURD/wclist/wclist"
 
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
@@ -13,10 +16,31 @@ import (
 type Server struct {
 	Config *config.Config
 	Server *echo.Echo
+
+	mu           sync.RWMutex
+	lists        []*storedList
+	nextID       int
+	reviews      []*reviewEntry
+	nextReviewID int
+	blobs        *wclist.BlobStore
+	cache        wclist.ParseCache
+	jobs         []*reprocessJob
+	nextJobID    int
 }
 
 func NewServer(config *config.Config) *Server {
-	return &Server{Config: config}
+	s := &Server{Config: config}
+	if config.BlobDir != "" {
+		s.blobs = wclist.NewBlobStore(config.BlobDir)
+	}
+	switch {
+	case config.CacheBytes <= 0:
+	case config.CacheDir != "":
+		s.cache = wclist.NewDiskCache(config.CacheDir, config.CacheBytes)
+	default:
+		s.cache = wclist.NewMemoryCache(config.CacheBytes)
+	}
+	return s
 }
 
 func (s *Server) SetupRoutes() {
@@ -32,6 +56,23 @@ func (s *Server) SetupRoutes() {
 	// API routes group
 	api := s.Server.Group("/api/v1")
 	api.GET("/status", s.handleAPIStatus)
+	api.GET("/lists", s.handleLists)
+	api.POST("/lists", s.handleUploadList)
+	api.GET("/items", s.handleItems)
+	api.GET("/lists/:id/items/:matter/source", s.handleItemSource)
+	api.GET("/sittings", s.handleSittings)
+	api.GET("/sittings/:key", s.handleSittingHistory)
+	api.POST("/lists/:id/highlight", s.handleHighlightList)
+	api.POST("/items/stream", s.handleStreamItems)
+	api.GET("/items/export", s.handleExportItems)
+	api.POST("/matches/export", s.handleExportMatches)
+	api.GET("/review", s.handleReviewQueue)
+	api.GET("/review/:id", s.handleReviewEntry)
+	api.PATCH("/review/:id", s.handleCorrectReview)
+	api.POST("/review/:id/accept", s.handleAcceptReview)
+	api.POST("/review/:id/dismiss", s.handleDismissReview)
+	api.POST("/reprocess", s.handleReprocess)
+	api.GET("/reprocess/:id", s.handleReprocessJob)
 }
 
 // Handler for home route
@@ -47,7 +88,7 @@ func (s *Server) handleHome(c echo.Context) error {
 func (s *Server) handleHealth(c echo.Context) error {
 	return c.JSON(http.StatusOK, map[string]interface{}{
 		"status":    "healthy",
-		"timestamp": fmt.Sprintf("%d", s.Config.Port),
+		"timestamp": time.Now().Format(time.RFC3339),
 	})
 }
 
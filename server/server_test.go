@@ -0,0 +1,74 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/joshuamURD/wclist/config"
+	"github.com/joshuamURD/wclist/wclist"
+
+	"github.com/labstack/echo/v4"
+)
+
+// sittingDate is the day of the sitting the test lists are for
+var sittingDate = time.Date(2025, time.June, 24, 0, 0, 0, 0, time.UTC)
+
+// newTestServer returns a server with its routes set up, keeping documents
+// in blobDir when it isn't empty
+func newTestServer(t *testing.T, blobDir string) *Server {
+	t.Helper()
+	cfg := config.NewConfig()
+	cfg.BlobDir = blobDir
+	s := NewServer(cfg)
+	if err := s.LoadLists(); err != nil {
+		t.Fatalf("Failed to load lists: %v", err)
+	}
+	s.Server = echo.New()
+	s.SetupRoutes()
+	return s
+}
+
+// request sends a request to the server and decodes its JSON response into
+// response, failing the test unless the response has the wanted status
+func request(t *testing.T, s *Server, method, path, body string, status int, response interface{}) {
+	t.Helper()
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	if body != "" {
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	}
+	rec := httptest.NewRecorder()
+	s.Server.ServeHTTP(rec, req)
+	if rec.Code != status {
+		t.Fatalf("Expected %s %s to give %d, got %d: %s", method, path, status, rec.Code, rec.Body)
+	}
+	if response != nil {
+		if err := json.Unmarshal(rec.Body.Bytes(), response); err != nil {
+			t.Fatalf("Failed to decode the response to %s %s: %v", method, path, err)
+		}
+	}
+}
+
+// testItem builds an objection read from a line of page 2
+func testItem(t *testing.T, line int, raw string, cells map[string]string) wclist.CauseListItem {
+	t.Helper()
+	item, err := wclist.ItemFromCells("objection", cells, wclist.Provenance{Page: 2, FirstLine: line, LastLine: line, Raw: raw})
+	if err != nil {
+		t.Fatalf("Failed to build item: %v", err)
+	}
+	return item
+}
+
+// testList returns a list for the Kalgoorlie sitting of sittingDate
+// released on the given day, with the given items and rows that couldn't be
+// parsed
+func testList(released time.Time, items []wclist.CauseListItem, rowErrors ...wclist.RowParseError) *wclist.CauseList {
+	cl := wclist.NewCauseList("Western Australia", "Hartley", released)
+	cl.Location, cl.SittingDate = "Kalgoorlie", sittingDate
+	cl.Items = items
+	cl.Report = wclist.ParseReport{Format: "pdf", RowErrors: rowErrors}
+	cl.ParserVersion = wclist.ParserVersion
+	return cl
+}
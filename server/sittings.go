@@ -0,0 +1,76 @@
+package server
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/joshuamURD/wclist/wclist"
+
+	"github.com/labstack/echo/v4"
+)
+
+// sittingVersion is one version of a sitting's cause list, with the changes
+// from the version before it
+type sittingVersion struct {
+	ListID        string              `json:"list_id"`
+	Version       int                 `json:"version"`
+	Current       bool                `json:"current"`
+	ReleaseDate   time.Time           `json:"release_date"`
+	ParserVersion int                 `json:"parser_version"`
+	Items         int                 `json:"items"`
+	Changes       []wclist.ItemChange `json:"changes"` // Empty for the first version
+}
+
+// handleSittings lists the sittings the server holds cause lists for, with
+// how many versions each has and the ID of the latest
+func (s *Server) handleSittings(c echo.Context) error {
+	sittings := []map[string]interface{}{}
+	for _, current := range s.currentLists() {
+		sittings = append(sittings, map[string]interface{}{
+			"key":             current.sittingKey(),
+			"sitting":         current.Sitting,
+			"versions":        current.Version,
+			"current_list_id": current.ID,
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"count":    len(sittings),
+		"sittings": sittings,
+	})
+}
+
+// handleSittingHistory returns every version of a sitting's cause list,
+// oldest first, with the items added, removed or changed by each
+func (s *Server) handleSittingHistory(c echo.Context) error {
+	s.mu.RLock()
+	versions := s.sittingVersions(c.Param("key"))
+	s.mu.RUnlock()
+	if len(versions) == 0 {
+		return echo.NewHTTPError(http.StatusNotFound, "sitting not found")
+	}
+
+	history := make([]sittingVersion, len(versions))
+	for i, stored := range versions {
+		history[i] = sittingVersion{
+			ListID:        stored.ID,
+			Version:       stored.Version,
+			Current:       i == len(versions)-1,
+			ReleaseDate:   stored.List.ReleaseDate,
+			ParserVersion: stored.List.ParserVersion,
+			Items:         len(stored.List.Items),
+			Changes:       []wclist.ItemChange{},
+		}
+		if i > 0 {
+			if changes := wclist.CompareItems(versions[i-1].List.Items, stored.List.Items); changes != nil {
+				history[i].Changes = changes
+			}
+		}
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"key":      versions[0].sittingKey(),
+		"sitting":  versions[0].Sitting,
+		"versions": history,
+	})
+}
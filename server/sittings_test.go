@@ -0,0 +1,88 @@
+package server
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/joshuamURD/wclist/wclist"
+)
+
+// sittingHistory is the response of GET /api/v1/sittings/{key}
+type sittingHistory struct {
+	Key      string           `json:"key"`
+	Versions []sittingVersion `json:"versions"`
+}
+
+func TestSittingHistory(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2025, time.June, d, 0, 0, 0, 0, time.UTC) }
+	objection := func(matter, objector string) wclist.CauseListItem {
+		return testItem(t, 10, matter+" "+objector, map[string]string{
+			"matter_number": matter, "objection_number": "69856" + matter, "objector": objector,
+			"tenement": "E 15/2082", "applicant": "FMG RESOURCES PTY LTD",
+		})
+	}
+	unparsed := wclist.RowParseError{Page: 2, Line: 20, Section: "objection", Raw: "9 ACME PTY LTD", Reason: "missing tenement"}
+
+	s := newTestServer(t, "")
+	// Added out of the order they were released
+	latest := s.AddCauseList(testList(day(22), []wclist.CauseListItem{objection("1", "KARORA PTY LTD")}, unparsed))
+	first := s.AddCauseList(testList(day(18), []wclist.CauseListItem{objection("1", "KARORA"), objection("2", "BEACON MINERALS LIMITED")}, unparsed))
+	second := s.AddCauseList(testList(day(20), []wclist.CauseListItem{objection("1", "KARORA"), objection("2", "BEACON MINERALS LIMITED")}))
+	key := testList(day(18), nil).Sitting().Key()
+
+	var history sittingHistory
+	request(t, s, http.MethodGet, "/api/v1/sittings/"+key, "", http.StatusOK, &history)
+	if history.Key != key || len(history.Versions) != 3 {
+		t.Fatalf("Expected 3 versions of %s, got %+v", key, history)
+	}
+	for i, want := range []string{first, second, latest} {
+		version := history.Versions[i]
+		if version.ListID != want || version.Version != i+1 || version.Current != (i == 2) {
+			t.Fatalf("Expected list %s to be version %d, got %+v", want, i+1, version)
+		}
+	}
+	if changes := history.Versions[1].Changes; len(changes) != 0 {
+		t.Fatalf("Expected the second version to be unchanged, got %+v", changes)
+	}
+	changes := history.Versions[2].Changes
+	if len(changes) != 2 || changes[0].Change != wclist.ItemChanged || changes[0].Matter != "1" ||
+		changes[1].Change != wclist.ItemRemoved || changes[1].Matter != "2" {
+		t.Fatalf("Expected the latest version to change matter 1 and remove matter 2, got %+v", changes)
+	}
+
+	t.Run("Only the latest version is current", func(t *testing.T) {
+		var items itemList
+		request(t, s, http.MethodGet, "/api/v1/items", "", http.StatusOK, &items)
+		if objector, _ := items.objector("1"); items.Count != 1 || objector != "KARORA PTY LTD" {
+			t.Fatalf("Expected only the items of the latest version, got %+v", items.Items)
+		}
+
+		var queue reviewQueue
+		request(t, s, http.MethodGet, "/api/v1/review?status=all", "", http.StatusOK, &queue)
+		for _, entry := range queue.Entries {
+			if want := map[string]string{latest: reviewPending, first: reviewSuperseded}[entry.ListID]; entry.Status != want {
+				t.Fatalf("Expected the review of list %s to be %s, got %s", entry.ListID, want, entry.Status)
+			}
+		}
+		if queue.Count != 2 {
+			t.Fatalf("Expected a review for each list with the row, got %+v", queue.Entries)
+		}
+	})
+
+	t.Run("Unknown sittings", func(t *testing.T) {
+		unknown := wclist.NewCauseList("Western Australia", "", day(18))
+		unknown.Items = []wclist.CauseListItem{objection("1", "KARORA")}
+		id := s.AddCauseList(unknown)
+		other := wclist.NewCauseList("Western Australia", "", day(18))
+		other.Items = unknown.Items
+		s.AddCauseList(other)
+
+		var history sittingHistory
+		request(t, s, http.MethodGet, "/api/v1/sittings/list-"+id, "", http.StatusOK, &history)
+		if len(history.Versions) != 1 || history.Versions[0].ListID != id || history.Versions[0].Version != 1 {
+			t.Fatalf("Expected a list for an unknown sitting to be a sitting of its own, got %+v", history)
+		}
+		request(t, s, http.MethodGet, "/api/v1/sittings/2025-06-18__", "", http.StatusNotFound, nil)
+	})
+}
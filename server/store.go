@@ -0,0 +1,210 @@
+package server
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+
+	"github.com/joshuamURD/wclist/wclist"
+)
+
+// storedList is a cause list held by the server
+type storedList struct {
+	ID   string
+	List *wclist.CauseList
+
+	// Lists for the same known sitting are versions of it, numbered from 1
+	// in the order they were released. The latest version is authoritative.
+	// A list whose sitting isn't known is a sitting of its own.
+	Sitting wclist.Sitting
+	Version int
+
+	// The document the list was read from, for highlighting matches and
+	// reading it again, is kept in the blob store under Blob, or in Document
+	// when the server has no blob store
+	Document []byte
+	Blob     string
+
+	options wclist.ReadOptions // Password, profile and strict mode the list was read with
+
+	// passwordLost is set on lists loaded from the list index that were read
+	// from an encrypted PDF, as their password isn't kept
+	passwordLost bool
+}
+
+// AddCauseList makes a parsed cause list available to the API and returns
+// its ID
+func (s *Server) AddCauseList(cl *wclist.CauseList) string {
+	return s.AddCauseListDocument(cl, nil)
+}
+
+// AddCauseListDocument makes a parsed cause list available to the API along
+// with the document it was read from, so that its matches can be
+// highlighted in a copy of a PDF and it can be reprocessed, and returns its
+// ID. A list for a sitting the server already holds is added as its next
+// version, replacing the versions released before it in searches. Rows that couldn't
+// be parsed and low-confidence items are queued for review.
+func (s *Server) AddCauseListDocument(cl *wclist.CauseList, document []byte) string {
+	return s.addList(cl, document, wclist.ReadOptions{})
+}
+
+// addList stores a cause list with the document and options it was read
+// with. Documents are kept in the blob store when the server has one, or
+// in memory if they can't be stored there. A version of a sitting read
+// again from the same document with the same options isn't added again, so
+// that lists loaded from the list index aren't duplicated by the document
+// the server is started with.
+func (s *Server) addList(cl *wclist.CauseList, document []byte, opts wclist.ReadOptions) string {
+	stored := &storedList{List: cl, Sitting: cl.Sitting(), Document: document, options: opts}
+	if document != nil && s.blobs != nil {
+		if digest, err := s.blobs.Put(document); err != nil {
+			fmt.Printf("Keeping document in memory, blob store failed: %v\n", err)
+		} else {
+			stored.Document, stored.Blob = nil, digest
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, version := range s.lists {
+		if stored.Blob != "" && version.Blob == stored.Blob && version.Sitting.Key() == stored.Sitting.Key() && sameOptions(version.options, opts) {
+			return version.ID
+		}
+	}
+	s.nextID++
+	stored.ID = strconv.Itoa(s.nextID)
+	s.lists = append(s.lists, stored)
+	s.queueReviews(stored)
+
+	s.numberVersions(stored.sittingKey())
+	s.saveLists()
+	return stored.ID
+}
+
+// sameOptions reports whether two reads give the same items
+func sameOptions(a, b wclist.ReadOptions) bool {
+	return a.Profile == b.Profile && a.Strict == b.Strict && a.MinConfidence == b.MinConfidence && a.Password == b.Password
+}
+
+// sittingKey identifies the sitting of the list, or the list itself when
+// its sitting isn't known, so that lists for sittings that can't be told
+// apart are never taken for versions of each other
+func (stored *storedList) sittingKey() string {
+	if !stored.Sitting.Known() {
+		return "list-" + stored.ID
+	}
+	return stored.Sitting.Key()
+}
+
+// sittingVersions returns the lists held for a sitting in the order they
+// were released, those released on the same day in the order they were
+// added. The caller holds s.mu.
+func (s *Server) sittingVersions(key string) []*storedList {
+	var versions []*storedList
+	for _, stored := range s.lists {
+		if stored.sittingKey() == key {
+			versions = append(versions, stored)
+		}
+	}
+	slices.SortStableFunc(versions, func(a, b *storedList) int {
+		return a.List.ReleaseDate.Compare(b.List.ReleaseDate)
+	})
+	return versions
+}
+
+// numberVersions numbers the lists held for a sitting in the order they
+// were released and returns them in that order, marking pending reviews of
+// all but the latest superseded. Lists whose number changes are replaced by
+// renumbered copies, as stored lists may be read without s.mu. The caller
+// holds s.mu.
+func (s *Server) numberVersions(key string) []*storedList {
+	versions := s.sittingVersions(key)
+	for i, version := range versions {
+		if version.Version == i+1 {
+			continue
+		}
+		c := *version
+		c.Version = i + 1
+		s.lists[slices.Index(s.lists, version)] = &c
+		versions[i] = &c
+	}
+
+	// Rows of versions released before the latest are no longer worth
+	// reviewing
+	for _, entry := range s.reviews {
+		if entry.Status == reviewPending && slices.ContainsFunc(versions[:len(versions)-1], func(version *storedList) bool { return version.ID == entry.ListID }) {
+			entry.Status = reviewSuperseded
+		}
+	}
+	return versions
+}
+
+// document returns the document a stored list was read from, or nil if it
+// wasn't kept
+func (s *Server) document(stored *storedList) ([]byte, error) {
+	if stored.Blob == "" {
+		return stored.Document, nil
+	}
+	if s.blobs == nil {
+		return nil, fmt.Errorf("no blob store for document %s", stored.Blob)
+	}
+	return s.blobs.Get(stored.Blob)
+}
+
+// withList returns a copy of the stored list holding another reading of
+// the same document
+func (stored *storedList) withList(cl *wclist.CauseList) *storedList {
+	c := *stored
+	c.List = cl
+	return &c
+}
+
+// CauseLists returns the latest version of each sitting's cause list held
+// by the server
+func (s *Server) CauseLists() []*wclist.CauseList {
+	var lists []*wclist.CauseList
+	for _, stored := range s.currentLists() {
+		lists = append(lists, stored.List)
+	}
+	return lists
+}
+
+// currentLists returns the latest version of each sitting's cause list
+// along with their IDs, in the order they were added
+func (s *Server) currentLists() []*storedList {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	versions := map[string]int{}
+	for _, stored := range s.lists {
+		versions[stored.sittingKey()]++
+	}
+	var lists []*storedList
+	for _, stored := range s.lists {
+		if stored.Version == versions[stored.sittingKey()] {
+			lists = append(lists, stored)
+		}
+	}
+	return lists
+}
+
+// storedLists returns the cause lists held by the server along with their IDs
+func (s *Server) storedLists() []*storedList {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return append([]*storedList(nil), s.lists...)
+}
+
+// storedList returns the cause list with the given ID, or nil
+func (s *Server) storedList(id string) *storedList {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, stored := range s.lists {
+		if stored.ID == id {
+			return stored
+		}
+	}
+	return nil
+}
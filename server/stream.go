@@ -0,0 +1,109 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/joshuamURD/wclist/wclist"
+
+	"github.com/labstack/echo/v4"
+)
+
+// handleStreamItems parses a cause list uploaded as the multipart "file"
+// field and streams the result as newline-delimited JSON while the document
+// is still being parsed. A "password" field opens encrypted PDFs and a
+// "profile" field names the layout profile. Each line is an item in the
+// MarshalItem encoding. When a JSON array of assigned matters is given in the
+// "matters" field, only matches are streamed instead, one object per line.
+// Pages that can't be parsed are reported with a {"page", "error"} line and
+// skipped. Errors once the response has started end it with an {"error"}
+// line, and parsing stops when the client goes away.
+func (s *Server) handleStreamItems(c echo.Context) error {
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "missing cause list file")
+	}
+
+	var assignedMatters []wclist.AssignedMatter
+	if matters := c.FormValue("matters"); matters != "" {
+		if err := json.Unmarshal([]byte(matters), &assignedMatters); err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, "invalid assigned matters")
+		}
+	}
+
+	// Reject oversized documents before the response has started
+	if s.Config.MaxUploadBytes > 0 && fileHeader.Size > s.Config.MaxUploadBytes {
+		return readError(wclist.ErrTooLarge, s.Config.ParseTimeout)
+	}
+	opts, err := s.readOptions(c)
+	if err != nil {
+		return err
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	res := c.Response()
+	res.Header().Set(echo.HeaderContentType, "application/x-ndjson")
+	res.WriteHeader(http.StatusOK)
+
+	cl := wclist.NewCauseList(c.FormValue("jurisdiction"), c.FormValue("warden"), time.Now())
+	encoder := json.NewEncoder(res)
+	ctx := c.Request().Context()
+
+	for item, err := range cl.StreamItemsOptions(ctx, file, fileHeader.Size, opts) {
+		if ctx.Err() != nil {
+			// The client has gone away
+			return nil
+		}
+		var pageErr wclist.PageError
+		if errors.As(err, &pageErr) {
+			encoder.Encode(pageErr)
+			res.Flush()
+			continue
+		}
+		if err != nil {
+			streamError(res, encoder, err)
+			return nil
+		}
+
+		if assignedMatters == nil {
+			data, err := wclist.MarshalItem(item)
+			if err != nil {
+				streamError(res, encoder, err)
+				return nil
+			}
+			res.Write(append(data, '\n'))
+		} else {
+			for _, match := range wclist.NewSearchIndex([]wclist.CauseListItem{item}).Search(assignedMatters) {
+				data, err := wclist.MarshalItem(match.CauseListItem)
+				if err != nil {
+					streamError(res, encoder, err)
+					return nil
+				}
+				encoder.Encode(map[string]interface{}{
+					"client":       match.AssignedMatter.ClientName,
+					"tenement":     match.AssignedMatter.TenementNumber,
+					"match_reason": match.MatchReason,
+					"item":         json.RawMessage(data),
+				})
+			}
+		}
+		res.Flush()
+	}
+
+	return nil
+}
+
+// streamError ends a streamed response with an {"error"} line. The status
+// and headers have already been sent, so the error can't be returned to
+// echo's error handler.
+func streamError(res *echo.Response, encoder *json.Encoder, err error) {
+	encoder.Encode(map[string]string{"error": err.Error()})
+	res.Flush()
+}
@@ -0,0 +1,154 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/joshuamURD/wclist/wclist"
+
+	"github.com/labstack/echo/v4"
+)
+
+// handleUploadList parses a cause list uploaded as the multipart "file" field
+// and stores it. Optional "jurisdiction", "warden", "location",
+// "release_date" and "sitting_date" (YYYY-MM-DD) fields describe the list.
+// The warden, location and sitting date named on the cover of the document
+// take precedence, and the release date is otherwise when the document was
+// last modified, or the time of the upload. A list for the same known
+// sitting as a stored one is stored as another version of it, ordered by
+// release date. A "password" field opens encrypted PDFs and a "profile"
+// field names the layout profile to read it with instead of detecting one.
+// A "strict" field of true rejects rows with a low confidence.
+// Parsing is abandoned with 504 Gateway Timeout once Config.ParseTimeout has
+// passed, and documents over Config.MaxUploadBytes are rejected with 413
+// Request Entity Too Large. The response includes the parse report, listing
+// any pages that were skipped, and its ETag is the report's cache key when
+// it has one. Documents uploaded before with the same options, other than
+// encrypted PDFs, are returned from the parse cache. Uploaded documents are
+// kept for highlighting matches in PDFs and for reprocessing.
+func (s *Server) handleUploadList(c echo.Context) error {
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "missing cause list file")
+	}
+
+	var releaseDate time.Time
+	if value := c.FormValue("release_date"); value != "" {
+		if releaseDate, err = time.Parse("2006-01-02", value); err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, "release_date must be YYYY-MM-DD")
+		}
+	}
+	var sittingDate time.Time
+	if value := c.FormValue("sitting_date"); value != "" {
+		if sittingDate, err = time.Parse("2006-01-02", value); err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, "sitting_date must be YYYY-MM-DD")
+		}
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	ctx, cancel := context.WithTimeout(c.Request().Context(), s.Config.ParseTimeout)
+	defer cancel()
+
+	opts, err := s.readOptions(c)
+	if err != nil {
+		return err
+	}
+
+	cl := wclist.NewCauseList(c.FormValue("jurisdiction"), c.FormValue("warden"), releaseDate)
+	cl.Location, cl.SittingDate = c.FormValue("location"), sittingDate
+	if err := cl.ReadCauseListOptions(ctx, file, fileHeader.Size, opts); err != nil {
+		return readError(err, s.Config.ParseTimeout)
+	}
+	if cl.ReleaseDate.IsZero() {
+		cl.ReleaseDate = time.Now()
+	}
+
+	// Documents are kept so that PDFs can be highlighted and every list
+	// can be reprocessed
+	document, err := io.ReadAll(io.NewSectionReader(file, 0, fileHeader.Size))
+	if err != nil {
+		return err
+	}
+
+	id := s.addList(cl, document, opts)
+	if cl.Report.CacheKey != "" {
+		c.Response().Header().Set("ETag", strconv.Quote(cl.Report.CacheKey))
+	}
+	stored := s.storedList(id)
+	return c.JSON(http.StatusCreated, map[string]interface{}{
+		"id":      id,
+		"sitting": stored.sittingKey(),
+		"version": stored.Version,
+		"items":   len(cl.Items),
+		"report":  cl.Report,
+	})
+}
+
+// readOptions returns the limits for parsing uploaded cause lists, along
+// with the password from the request's "password" field and strict mode
+// from its "strict" field
+func (s *Server) readOptions(c echo.Context) (wclist.ReadOptions, error) {
+	opts := wclist.ReadOptions{
+		MaxBytes:    s.Config.MaxUploadBytes,
+		MaxPages:    s.Config.MaxPages,
+		PageTimeout: s.Config.PageTimeout,
+		OCR:         s.Config.OCR,
+		Password:    c.FormValue("password"),
+		Cache:       s.cache,
+	}
+
+	if value := c.FormValue("strict"); value != "" {
+		strict, err := strconv.ParseBool(value)
+		if err != nil {
+			return opts, echo.NewHTTPError(http.StatusBadRequest, "strict must be true or false")
+		}
+		opts.Strict = strict
+	}
+
+	if name := c.FormValue("profile"); name != "" {
+		if opts.Profile = wclist.ProfileByName(name); opts.Profile == nil {
+			return opts, echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("unknown profile %q", name))
+		}
+	}
+	return opts, nil
+}
+
+// readError converts an error from reading an uploaded cause list into an
+// HTTP error. The body carries a machine-readable code alongside the message.
+func readError(err error, timeout time.Duration) *echo.HTTPError {
+	status, code := http.StatusUnprocessableEntity, "unreadable"
+	message := err.Error()
+
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		status, code = http.StatusGatewayTimeout, "timeout"
+		message = fmt.Sprintf("parsing the cause list took longer than %s", timeout)
+	case errors.Is(err, wclist.ErrTooLarge):
+		status, code = http.StatusRequestEntityTooLarge, "too_large"
+	case errors.Is(err, wclist.ErrNotACauseList):
+		status, code = http.StatusUnsupportedMediaType, "not_a_cause_list"
+	case errors.Is(err, wclist.ErrWrongPassword):
+		code = "wrong_password"
+	case errors.Is(err, wclist.ErrEncryptedPDF):
+		code = "encrypted_pdf"
+	case errors.Is(err, wclist.ErrMalformedPDF):
+		code = "malformed_pdf"
+	case errors.Is(err, wclist.ErrNoItemsFound):
+		code = "no_items_found"
+	}
+
+	return echo.NewHTTPError(status, map[string]string{
+		"error":   code,
+		"message": message,
+	})
+}
@@ -0,0 +1,98 @@
+package wclist
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// BlobStore keeps documents in a directory by the SHA-256 of their
+// content, so that the same document is only stored once and stored lists
+// can be read again by later parsers. Blobs are kept in subdirectories
+// named by the first two characters of their digest.
+type BlobStore struct {
+	dir string
+}
+
+// NewBlobStore returns a blob store in a directory, which is created when
+// the first blob is stored
+func NewBlobStore(dir string) *BlobStore {
+	return &BlobStore{dir: dir}
+}
+
+// digestPattern matches the hex SHA-256 digests that name blobs
+var digestPattern = regexp.MustCompile(`^[0-9a-f]{64}$`)
+
+// path returns where the blob with a digest is kept
+func (s *BlobStore) path(digest string) (string, error) {
+	if !digestPattern.MatchString(digest) {
+		return "", fmt.Errorf("invalid blob digest %q", digest)
+	}
+	return filepath.Join(s.dir, digest[:2], digest), nil
+}
+
+// Put stores a document and returns its digest. Storing a document that is
+// already held does nothing.
+func (s *BlobStore) Put(data []byte) (string, error) {
+	sum := sha256.Sum256(data)
+	digest := hex.EncodeToString(sum[:])
+	path, _ := s.path(digest)
+	if _, err := os.Stat(path); err == nil {
+		return digest, nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return "", err
+	}
+	// Blobs are written under another name and renamed into place, so a
+	// blob is never seen half written
+	tmp, err := os.CreateTemp(filepath.Dir(path), digest+".tmp*")
+	if err != nil {
+		return "", err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return "", err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return "", err
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		os.Remove(tmp.Name())
+		return "", err
+	}
+	return digest, nil
+}
+
+// Get returns the document with a digest. Errors for documents that aren't
+// held match os.ErrNotExist.
+func (s *BlobStore) Get(digest string) ([]byte, error) {
+	path, err := s.path(digest)
+	if err != nil {
+		return nil, err
+	}
+	return os.ReadFile(path)
+}
+
+// Digests returns the digests of every document held, in order
+func (s *BlobStore) Digests() ([]string, error) {
+	paths, err := filepath.Glob(filepath.Join(s.dir, "*", "*"))
+	if err != nil {
+		return nil, err
+	}
+	var digests []string
+	for _, path := range paths {
+		if name := filepath.Base(path); digestPattern.MatchString(name) && strings.HasPrefix(name, filepath.Base(filepath.Dir(path))) {
+			digests = append(digests, name)
+		}
+	}
+	slices.Sort(digests)
+	return digests, nil
+}
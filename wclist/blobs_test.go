@@ -0,0 +1,45 @@
+package wclist
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestBlobStore(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "blobs")
+	store := NewBlobStore(dir)
+
+	digest, err := store.Put([]byte("%PDF-1.4 cause list"))
+	if err != nil {
+		t.Fatalf("Failed to store blob: %v", err)
+	}
+	again, err := store.Put([]byte("%PDF-1.4 cause list"))
+	if err != nil || again != digest {
+		t.Fatalf("Expected the same document to be stored once as %s, got %s (%v)", digest, again, err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, digest[:2], digest)); err != nil {
+		t.Fatalf("Expected the blob to be kept by its digest: %v", err)
+	}
+
+	data, err := store.Get(digest)
+	if err != nil || string(data) != "%PDF-1.4 cause list" {
+		t.Fatalf("Expected the document back, got %q (%v)", data, err)
+	}
+	other, _ := store.Put([]byte("<html>cause list</html>"))
+	if digests, err := store.Digests(); err != nil || !slices.Equal(digests, slices.Sorted(slices.Values([]string{digest, other}))) {
+		t.Fatalf("Expected both digests, got %v (%v)", digests, err)
+	}
+
+	t.Run("Missing", func(t *testing.T) {
+		missing := "0000000000000000000000000000000000000000000000000000000000000000"
+		if _, err := store.Get(missing); !errors.Is(err, os.ErrNotExist) {
+			t.Fatalf("Expected a missing blob to not exist, got %v", err)
+		}
+		if _, err := store.Get("../" + digest); err == nil {
+			t.Fatal("Expected an invalid digest to be rejected")
+		}
+	})
+}
@@ -0,0 +1,320 @@
+package wclist
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ParseCache holds the results of reading documents, keyed by CacheKey, so
+// that a document read again returns without being parsed. Entries are
+// opaque encodings; caches may drop them at any time.
+type ParseCache interface {
+	Get(key string) ([]byte, bool)
+	Put(key string, entry []byte) error
+}
+
+// CacheKey returns the key the result of reading a document with the given
+// options is cached under: the SHA-256 of the document, the parser version
+// and a digest of everything else that changes which items are read. That
+// is the password, strict mode and OCR, the content of the profile, or of
+// every registered profile when one is detected, and the registered
+// section parsers. The key changes whenever the result could, so it can be
+// used as an ETag.
+func CacheKey(r io.ReaderAt, size int64, opts ReadOptions) (string, error) {
+	document := sha256.New()
+	if _, err := io.Copy(document, io.NewSectionReader(r, 0, size)); err != nil {
+		return "", err
+	}
+
+	profiles := []*Profile{opts.Profile}
+	if opts.Profile == nil {
+		profiles = Profiles()
+	}
+	for i, profile := range profiles {
+		compiled, err := profile.compiled()
+		if err != nil {
+			return "", err
+		}
+		profiles[i] = compiled
+	}
+	encodedProfiles, err := json.Marshal(profiles)
+	if err != nil {
+		return "", err
+	}
+	var parsers []string
+	for _, parser := range SectionParsers() {
+		parsers = append(parsers, fmt.Sprintf("%T %s %q %q %q", parser, parser.Type(), parser.Headings(), parser.HeaderKeywords(), parser.Columns()))
+	}
+
+	options := sha256.Sum256(fmt.Appendf(nil, "password=%q strict=%t min_confidence=%g ocr=%t profiles=%s parsers=%q",
+		opts.Password, opts.Strict, opts.minConfidence(), opts.OCR != nil, encodedProfiles, parsers))
+
+	return fmt.Sprintf("%x-v%d-%s", document.Sum(nil), ParserVersion, hex.EncodeToString(options[:4])), nil
+}
+
+// cacheEntry is the encoding of a cached read
+type cacheEntry struct {
+	List   *CauseList  `json:"list"`
+	Report ParseReport `json:"report"`
+}
+
+// cachedRead returns the items and report cached under a key. Entries that
+// can't be decoded are reported as an error and treated as missing.
+func cachedRead(cache ParseCache, key string) ([]CauseListItem, ParseReport, bool, error) {
+	data, ok := cache.Get(key)
+	if !ok {
+		return nil, ParseReport{}, false, nil
+	}
+	entry := cacheEntry{List: &CauseList{}}
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return nil, ParseReport{}, false, fmt.Errorf("ignoring cached parse %s: %w", key, err)
+	}
+	return entry.List.Items, entry.Report, true, nil
+}
+
+// cacheRead caches the items and report of a read. Reads with page errors
+// aren't cached, since pages may fail only for want of time.
+func cacheRead(cache ParseCache, key string, items []CauseListItem, report ParseReport) error {
+	if len(report.PageErrors) > 0 {
+		return nil
+	}
+	data, err := json.Marshal(cacheEntry{List: &CauseList{Items: items}, Report: report})
+	if err != nil {
+		return fmt.Errorf("not caching parse %s: %w", key, err)
+	}
+	if err := cache.Put(key, data); err != nil {
+		return fmt.Errorf("not caching parse %s: %w", key, err)
+	}
+	return nil
+}
+
+// MemoryCache is a ParseCache in memory that holds up to a number of bytes
+// of entries, dropping the least recently used first
+type MemoryCache struct {
+	maxBytes int64
+
+	mu      sync.Mutex
+	size    int64
+	order   *list.List // Most recently used at the front
+	entries map[string]*list.Element
+}
+
+// memoryEntry is an entry of a MemoryCache
+type memoryEntry struct {
+	key  string
+	data []byte
+}
+
+// NewMemoryCache returns an empty cache holding up to maxBytes of entries
+func NewMemoryCache(maxBytes int64) *MemoryCache {
+	return &MemoryCache{maxBytes: maxBytes, order: list.New(), entries: map[string]*list.Element{}}
+}
+
+// Get returns the entry with a key
+func (c *MemoryCache) Get(key string) ([]byte, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	element, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	c.order.MoveToFront(element)
+	return element.Value.(*memoryEntry).data, true
+}
+
+// Put adds an entry, dropping the least recently used entries to make room.
+// Entries larger than the cache aren't kept.
+func (c *MemoryCache) Put(key string, data []byte) error {
+	if int64(len(data)) > c.maxBytes {
+		return nil
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if element, ok := c.entries[key]; ok {
+		c.remove(element)
+	}
+	c.entries[key] = c.order.PushFront(&memoryEntry{key: key, data: data})
+	c.size += int64(len(data))
+	for c.size > c.maxBytes {
+		c.remove(c.order.Back())
+	}
+	return nil
+}
+
+// remove drops an entry. The caller holds c.mu.
+func (c *MemoryCache) remove(element *list.Element) {
+	entry := c.order.Remove(element).(*memoryEntry)
+	delete(c.entries, entry.key)
+	c.size -= int64(len(entry.data))
+}
+
+// DiskCache is a ParseCache in a directory that holds up to a number of
+// bytes of entries, removing the least recently used first. Entries are
+// kept across restarts. The entries already in the directory are found
+// when the cache is first used, and their sizes and use are tracked in
+// memory from then on.
+type DiskCache struct {
+	dir      string
+	maxBytes int64
+
+	mu      sync.Mutex
+	loaded  bool
+	size    int64
+	order   *list.List // Most recently used at the front
+	entries map[string]*list.Element
+}
+
+// diskEntry is an entry of a DiskCache
+type diskEntry struct {
+	key  string
+	size int64
+}
+
+// NewDiskCache returns a cache in a directory, which is created when the
+// first entry is added, holding up to maxBytes of entries
+func NewDiskCache(dir string, maxBytes int64) *DiskCache {
+	return &DiskCache{dir: dir, maxBytes: maxBytes, order: list.New(), entries: map[string]*list.Element{}}
+}
+
+// cacheKeyPattern matches the keys made by CacheKey
+var cacheKeyPattern = regexp.MustCompile(`^[0-9a-f]{64}-v\d+-[0-9a-f]{8}$`)
+
+// path returns the file an entry is kept in, or false for keys that
+// CacheKey doesn't make
+func (c *DiskCache) path(key string) (string, bool) {
+	if !cacheKeyPattern.MatchString(key) {
+		return "", false
+	}
+	return filepath.Join(c.dir, key+".json"), true
+}
+
+// load finds the entries already in the directory, ordered by when they
+// were last used. The caller holds c.mu.
+func (c *DiskCache) load() {
+	if c.loaded {
+		return
+	}
+	c.loaded = true
+
+	paths, err := filepath.Glob(filepath.Join(c.dir, "*.json"))
+	if err != nil {
+		return
+	}
+	type file struct {
+		key     string
+		size    int64
+		modTime time.Time
+	}
+	var files []file
+	for _, path := range paths {
+		key := strings.TrimSuffix(filepath.Base(path), ".json")
+		if info, err := os.Stat(path); err == nil && cacheKeyPattern.MatchString(key) {
+			files = append(files, file{key, info.Size(), info.ModTime()})
+		}
+	}
+	slices.SortFunc(files, func(a, b file) int { return a.modTime.Compare(b.modTime) })
+	for _, f := range files {
+		c.entries[f.key] = c.order.PushFront(&diskEntry{key: f.key, size: f.size})
+		c.size += f.size
+	}
+}
+
+// Get returns the entry with a key, marking it as recently used
+func (c *DiskCache) Get(key string) ([]byte, bool) {
+	path, ok := c.path(key)
+	if !ok {
+		return nil, false
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.load()
+	element, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		c.remove(element)
+		return nil, false
+	}
+	c.order.MoveToFront(element)
+	// The modification time records the use for the next process
+	now := time.Now()
+	os.Chtimes(path, now, now)
+	return data, true
+}
+
+// Put adds an entry, removing the least recently used entries to make room.
+// Entries larger than the cache aren't kept.
+func (c *DiskCache) Put(key string, data []byte) error {
+	path, ok := c.path(key)
+	if !ok {
+		return fmt.Errorf("invalid cache key %q", key)
+	}
+	if int64(len(data)) > c.maxBytes {
+		return nil
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.load()
+	if err := os.MkdirAll(c.dir, 0o755); err != nil {
+		return err
+	}
+	// Entries are written under another name and renamed into place, so an
+	// entry is never read half written
+	tmp, err := os.CreateTemp(c.dir, key+".tmp*")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+
+	if element, ok := c.entries[key]; ok {
+		c.size -= element.Value.(*diskEntry).size
+		c.order.Remove(element)
+	}
+	c.entries[key] = c.order.PushFront(&diskEntry{key: key, size: int64(len(data))})
+	c.size += int64(len(data))
+	for c.size > c.maxBytes {
+		element := c.order.Back()
+		os.Remove(filepath.Join(c.dir, element.Value.(*diskEntry).key+".json"))
+		c.remove(element)
+	}
+	return nil
+}
+
+// remove forgets an entry. The caller holds c.mu.
+func (c *DiskCache) remove(element *list.Element) {
+	entry := c.order.Remove(element).(*diskEntry)
+	delete(c.entries, entry.key)
+	c.size -= entry.size
+}
@@ -0,0 +1,217 @@
+package wclist
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCacheKey(t *testing.T) {
+	document := []byte("%PDF-1.4 cause list")
+	r := bytes.NewReader(document)
+	key, err := CacheKey(r, r.Size(), ReadOptions{})
+	if err != nil {
+		t.Fatalf("Failed to make cache key: %v", err)
+	}
+	if want := fmt.Sprintf("%x-v%d-", sha256.Sum256(document), ParserVersion); !strings.HasPrefix(key, want) {
+		t.Fatalf("Expected the key to start with the document digest and parser version %q, got %q", want, key)
+	}
+
+	same, _ := CacheKey(r, r.Size(), ReadOptions{MaxPages: 10})
+	password, _ := CacheKey(r, r.Size(), ReadOptions{Password: "secret"})
+	strict, _ := CacheKey(r, r.Size(), ReadOptions{Strict: true})
+	if same != key || password == key || strict == key {
+		t.Fatalf("Expected only options that change the items to change the key, got %q, %q, %q and %q", key, same, password, strict)
+	}
+
+	t.Run("Profile content", func(t *testing.T) {
+		profile := *DefaultProfile()
+		named, _ := CacheKey(r, r.Size(), ReadOptions{Profile: &profile})
+		profile.HeaderKeywords = append(slices.Clone(profile.HeaderKeywords), "RECEIVED")
+		changed, _ := CacheKey(r, r.Size(), ReadOptions{Profile: &profile})
+		if named == changed {
+			t.Fatalf("Expected a change to the profile to change the key, got %q", named)
+		}
+	})
+
+	t.Run("Registered parsers", func(t *testing.T) {
+		RegisterSectionParser(cacheKeyParser{})
+		if registered, _ := CacheKey(r, r.Size(), ReadOptions{}); registered == key {
+			t.Fatalf("Expected registering a parser to change the key, got %q", key)
+		}
+	})
+}
+
+// cacheKeyParser is a parser that no document uses, registered only to
+// change the set of parsers
+type cacheKeyParser struct{ calloverParser }
+
+func (cacheKeyParser) Type() string             { return "cache-key" }
+func (cacheKeyParser) Headings() []string       { return nil }
+func (cacheKeyParser) HeaderKeywords() []string { return nil }
+
+func TestParseCache(t *testing.T) {
+	var buf bytes.Buffer
+	if _, err := GenerateCauseList(&buf, GenerateOptions{Seed: 5, Sections: []string{"objection"}}); err != nil {
+		t.Fatalf("Failed to generate cause list: %v", err)
+	}
+	document := buf.Bytes()
+
+	read := func(t *testing.T, cache ParseCache) *CauseList {
+		t.Helper()
+		opts := DefaultReadOptions()
+		opts.OCR = nil
+		opts.Cache = cache
+		cl := NewCauseList("", "", time.Time{})
+		if err := cl.ReadCauseListOptions(context.Background(), bytes.NewReader(document), int64(len(document)), opts); err != nil {
+			t.Fatalf("Failed to read cause list: %v", err)
+		}
+		return cl
+	}
+
+	caches := []struct {
+		name string
+		new  func() ParseCache
+	}{
+		{"Memory", func() ParseCache { return NewMemoryCache(1 << 20) }},
+		{"Disk", func() ParseCache { return NewDiskCache(filepath.Join(t.TempDir(), "cache"), 1<<20) }},
+	}
+	for _, tt := range caches {
+		t.Run(tt.name, func(t *testing.T) {
+			cache := tt.new()
+			first := read(t, cache)
+			second := read(t, cache)
+			if first.Report.Cached || !second.Report.Cached {
+				t.Fatalf("Expected only the second read to come from the cache, got %v and %v", first.Report.Cached, second.Report.Cached)
+			}
+			if !reflect.DeepEqual(first.Items, second.Items) {
+				t.Fatal("Expected the cached items to equal the parsed items")
+			}
+			if second.Report.CacheKey != first.Report.CacheKey || second.Report.Pages != first.Report.Pages ||
+				len(second.Report.RowErrors) != len(first.Report.RowErrors) || second.ParserVersion != ParserVersion {
+				t.Fatalf("Expected the cached report to equal the parsed report, got %+v", second.Report)
+			}
+		})
+	}
+
+	t.Run("Encrypted PDFs", func(t *testing.T) {
+		encrypted := buildTestPDF(newTestPDFEncryption("secret", "owner"), []string{
+			textPage("WARDEN'S COURT KALGOORLIE"),
+			textPage("OBJECTIONS", "1", "698561", "KARORA (HIGGINSVILLE) PTY LTD", "E 15/2082", "FMG RESOURCES PTY LTD"),
+		}, nil)
+		cache := NewMemoryCache(1 << 20)
+		read := func(password string) (*CauseList, error) {
+			opts := DefaultReadOptions()
+			opts.OCR, opts.Cache, opts.Password = nil, cache, password
+			cl := NewCauseList("", "", time.Time{})
+			err := cl.ReadCauseListOptions(context.Background(), bytes.NewReader(encrypted), int64(len(encrypted)), opts)
+			return cl, err
+		}
+
+		cl, err := read("secret")
+		if err != nil || cl.Report.CacheKey != "" {
+			t.Fatalf("Expected the PDF to be read without a cache key, got %q (%v)", cl.Report.CacheKey, err)
+		}
+		if _, err := read("wrong"); !errors.Is(err, ErrWrongPassword) {
+			t.Fatalf("Expected ErrWrongPassword after a read with the right password, got %v", err)
+		}
+	})
+
+	t.Run("Cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		opts := DefaultReadOptions()
+		opts.OCR, opts.Cache = nil, NewMemoryCache(1<<20)
+		cl := NewCauseList("", "", time.Time{})
+		if err := cl.ReadCauseListOptions(ctx, bytes.NewReader(document), int64(len(document)), opts); !errors.Is(err, context.Canceled) {
+			t.Fatalf("Expected a cancelled read to stop before the cache is used, got %v", err)
+		}
+	})
+
+	t.Run("Disk cache is kept", func(t *testing.T) {
+		dir := filepath.Join(t.TempDir(), "cache")
+		read(t, NewDiskCache(dir, 1<<20))
+		if cl := read(t, NewDiskCache(dir, 1<<20)); !cl.Report.Cached {
+			t.Fatal("Expected a new cache in the same directory to hold the read")
+		}
+	})
+}
+
+func TestCacheLimits(t *testing.T) {
+	key := func(i int) string { return fmt.Sprintf("%064x-v1-%08x", i, 0) }
+	entry := bytes.Repeat([]byte("x"), 40)
+
+	t.Run("Memory", func(t *testing.T) {
+		cache := NewMemoryCache(100)
+		cache.Put(key(1), entry)
+		cache.Put(key(2), entry)
+		cache.Get(key(1)) // Entry 2 is now the least recently used
+		cache.Put(key(3), entry)
+		if _, ok := cache.Get(key(2)); ok {
+			t.Fatal("Expected the least recently used entry to be dropped")
+		}
+		if _, ok := cache.Get(key(1)); !ok {
+			t.Fatal("Expected the recently used entry to be kept")
+		}
+		cache.Put(key(4), bytes.Repeat(entry, 3))
+		if _, ok := cache.Get(key(4)); ok {
+			t.Fatal("Expected an entry larger than the cache not to be kept")
+		}
+	})
+
+	t.Run("Disk", func(t *testing.T) {
+		dir := t.TempDir()
+		cache := NewDiskCache(dir, 100)
+		cache.Put(key(1), entry)
+		cache.Put(key(2), entry)
+		cache.Get(key(1)) // Entry 2 is now the least recently used
+		cache.Put(key(3), entry)
+		if _, ok := cache.Get(key(2)); ok {
+			t.Fatal("Expected the least recently used entry to be removed")
+		}
+		if _, err := os.Stat(filepath.Join(dir, key(2)+".json")); !errors.Is(err, os.ErrNotExist) {
+			t.Fatalf("Expected the removed entry's file to be deleted, got %v", err)
+		}
+		if _, ok := cache.Get(key(1)); !ok {
+			t.Fatal("Expected the recently used entry to be kept")
+		}
+		if _, ok := cache.Get("../" + key(1)); ok {
+			t.Fatal("Expected keys not made by CacheKey to be refused")
+		}
+		if err := cache.Put("../"+key(1), entry); err == nil {
+			t.Fatal("Expected keys not made by CacheKey to be refused")
+		}
+		if tmp, _ := filepath.Glob(filepath.Join(dir, "*.tmp*")); len(tmp) > 0 {
+			t.Fatalf("Expected no temporary files to be left, got %v", tmp)
+		}
+	})
+
+	t.Run("Disk use across restarts", func(t *testing.T) {
+		dir := t.TempDir()
+		cache := NewDiskCache(dir, 100)
+		cache.Put(key(1), entry)
+		cache.Put(key(2), entry)
+		// Entry 1 was used an hour after entry 2
+		old := time.Now().Add(-2 * time.Hour)
+		os.Chtimes(filepath.Join(dir, key(1)+".json"), old, old.Add(time.Hour))
+		os.Chtimes(filepath.Join(dir, key(2)+".json"), old, old)
+
+		cache = NewDiskCache(dir, 100)
+		cache.Put(key(3), entry)
+		if _, ok := cache.Get(key(2)); ok {
+			t.Fatal("Expected the least recently used entry to be removed")
+		}
+		if _, ok := cache.Get(key(1)); !ok {
+			t.Fatal("Expected the recently used entry to be kept")
+		}
+	})
+}
@@ -2,14 +2,15 @@ package wclist
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"io"
 	"regexp"
+	"slices"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
-
-	"github.com/ledongthuc/pdf"
 )
 
 // CauseListItem represents any item that can be included in a cause list
@@ -21,12 +22,24 @@ type CauseListItem interface {
 	GetRespondingParty() string
 }
 
+// ParserVersion identifies how the parser reads documents. It is increased
+// whenever a parser change alters the items read from a document, so that
+// lists read by an older parser can be found and read again.
+const ParserVersion = 1
+
 // CauseList represents a cause list
 type CauseList struct {
-	Jurisdiction string
-	Warden       string
-	ReleaseDate  time.Time
-	Items        []CauseListItem
+	Jurisdiction  string
+	Warden        string
+	ReleaseDate   time.Time
+	Location      string    // Where the court sits
+	SittingDate   time.Time // Day of the sitting when the document doesn't name it
+	Items         []CauseListItem
+	Report        ParseReport // How the last document was read
+	ParserVersion int         // Version of the parser that read the items, 0 if unknown
+
+	indexMu sync.Mutex
+	index   *SearchIndex
 }
 
 // AssignedMatter represents a matter assigned to a lawyer (imported from lawyer package structure)
@@ -55,6 +68,12 @@ func NewCauseList(jurisdiction string, warden string, releaseDate time.Time) *Ca
 
 // SearchAssignedMatters searches for assigned matters in the cause list
 func (cl *CauseList) SearchAssignedMatters(assignedMatters []AssignedMatter) []MatchResult {
+	return cl.Index().Search(assignedMatters)
+}
+
+// searchLinear searches for assigned matters by comparing every matter
+// against every item. It is the reference behaviour for SearchIndex.
+func (cl *CauseList) searchLinear(assignedMatters []AssignedMatter) []MatchResult {
 	var results []MatchResult
 
 	for _, assignedMatter := range assignedMatters {
@@ -77,27 +96,32 @@ func (cl *CauseList) isMatch(assignedMatter AssignedMatter, item CauseListItem)
 	// Primary match: tenement number
 	if assignedMatter.TenementNumber != "" &&
 		strings.EqualFold(assignedMatter.TenementNumber, item.GetTenementNumber()) {
-		return true, "Tenement number match"
+		return true, reasonTenement
 	}
 
+	// Names are matched against each party rather than the whole field
+	applying, responding := partyNames(item)
+
 	// Secondary match: client name matches applying or responding party
 	if assignedMatter.ClientName != "" {
-		if cl.namesMatch(assignedMatter.ClientName, item.GetApplyingParty()) {
-			return true, "Client name matches applying party"
+		client := normalizeName(assignedMatter.ClientName)
+		if anyNameMatches(client, applying) {
+			return true, reasonClientApplying
 		}
-		if cl.namesMatch(assignedMatter.ClientName, item.GetRespondingParty()) {
-			return true, "Client name matches responding party"
+		if anyNameMatches(client, responding) {
+			return true, reasonClientResponding
 		}
 	}
 
 	// Tertiary match: other party names
 	for _, otherParty := range assignedMatter.OtherPartyNames {
 		if otherParty != "" {
-			if cl.namesMatch(otherParty, item.GetApplyingParty()) {
-				return true, "Other party matches applying party"
+			other := normalizeName(otherParty)
+			if anyNameMatches(other, applying) {
+				return true, reasonOtherPartyApplying
 			}
-			if cl.namesMatch(otherParty, item.GetRespondingParty()) {
-				return true, "Other party matches responding party"
+			if anyNameMatches(other, responding) {
+				return true, reasonOtherPartyResponding
 			}
 		}
 	}
@@ -105,16 +129,12 @@ func (cl *CauseList) isMatch(assignedMatter AssignedMatter, item CauseListItem)
 	return false, ""
 }
 
-// namesMatch performs a case-insensitive comparison of names, handling common variations
-func (cl *CauseList) namesMatch(name1, name2 string) bool {
-	if name1 == "" || name2 == "" {
+// normalizedNamesMatch compares two names that have already been normalised
+func normalizedNamesMatch(norm1, norm2 string) bool {
+	if norm1 == "" || norm2 == "" {
 		return false
 	}
 
-	// Normalize both names
-	norm1 := cl.normalizeName(name1)
-	norm2 := cl.normalizeName(name2)
-
 	// Exact match after normalization
 	if norm1 == norm2 {
 		return true
@@ -128,105 +148,194 @@ func (cl *CauseList) namesMatch(name1, name2 string) bool {
 	return false
 }
 
+var (
+	punctuationPattern = regexp.MustCompile(`[^\w\s]`)
+	whitespacePattern  = regexp.MustCompile(`\s+`)
+)
+
 // normalizeName normalizes a name for comparison
-func (cl *CauseList) normalizeName(name string) string {
+func normalizeName(name string) string {
 	// Convert to lowercase
 	name = strings.ToLower(name)
 
 	// Remove common punctuation and extra spaces
-	name = regexp.MustCompile(`[^\w\s]`).ReplaceAllString(name, " ")
-	name = regexp.MustCompile(`\s+`).ReplaceAllString(name, " ")
+	name = punctuationPattern.ReplaceAllString(name, " ")
+	name = whitespacePattern.ReplaceAllString(name, " ")
 	name = strings.TrimSpace(name)
 
 	return name
 }
 
-// ReadCauseList reads a cause list from a PDF file
+// ReadCauseList reads a cause list from a PDF, HTML or DOCX document.
+// The format is detected from the content of the document.
 func (cl *CauseList) ReadCauseList(file io.Reader, size int64) error {
-	data, err := io.ReadAll(file)
+	return cl.ReadCauseListContext(context.Background(), file, size)
+}
+
+// ReadCauseListContext reads a cause list like ReadCauseList, parsing PDF
+// pages concurrently. It stops and returns the context's error as soon as
+// the context is cancelled, leaving the cause list unchanged. Items are
+// appended in page and row order regardless of which page finishes first.
+func (cl *CauseList) ReadCauseListContext(ctx context.Context, file io.Reader, size int64) error {
+	return cl.ReadCauseListOptions(ctx, file, size, unlimitedReadOptions())
+}
+
+// ReadCauseListOptions reads a cause list like ReadCauseListContext within
+// the given limits. Pages that can't be parsed are skipped and recorded in
+// cl.Report; errors that stop the whole document being read leave the
+// cause list unchanged. ErrNoItemsFound is returned when the document was
+// read but held no items, in which case the report is still recorded.
+// Documents already in opts.Cache are returned from it, and documents read
+// in full are added to it. Encrypted PDFs, and PDFs whose trailer can't be
+// read, are kept out of the cache, so that a read is never returned without
+// the document's password being checked; looking for the encryption is
+// bounded by ctx like the read. A zero release date is taken from the
+// document when it records when it was last modified.
+func (cl *CauseList) ReadCauseListOptions(ctx context.Context, file io.Reader, size int64, opts ReadOptions) error {
+	if opts.MaxBytes > 0 && size > opts.MaxBytes {
+		return fmt.Errorf("%w: %d bytes, limit is %d", ErrTooLarge, size, opts.MaxBytes)
+	}
+
+	r, err := readerAt(file, opts.MaxBytes)
 	if err != nil {
 		return err
 	}
 
-	pdfReader, err := pdf.NewReader(bytes.NewReader(data), size)
+	format, err := DetectSourceFormat(r, size)
 	if err != nil {
 		return err
 	}
 
-	numPages := pdfReader.NumPage()
-	fmt.Printf("PDF has %d pages\n", numPages)
-
-	// Skip the first page (cover page) and process from page 2 onwards
-	for i := 2; i <= numPages; i++ {
-		fmt.Printf("Processing page %d...\n", i)
-
-		page := pdfReader.Page(i)
-		if page.V.IsNull() {
-			fmt.Printf("Page %d is null, skipping\n", i)
-			continue
+	// PDFs that might be encrypted, because their trailer can't be read, are
+	// read without the cache too
+	if _, ok := format.(pdfSource); ok && opts.Cache != nil {
+		encrypted, err := encryptedPDF(ctx, r, size)
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return ctxErr
 		}
-
-		// Extract text content from the page
-		content, err := page.GetPlainText(nil)
-		if err != nil {
-			fmt.Printf("Error getting text from page %d: %v\n", i, err)
-			continue // Skip pages that can't be read
+		if encrypted || err != nil {
+			opts.Cache = nil
 		}
-
-		// Parse the page content and extract items
-		items := cl.parsePageText(content)
-		cl.Items = append(cl.Items, items...)
-		fmt.Printf("Extracted %d items from page %d\n", len(items), i)
 	}
-
-	fmt.Printf("Total items extracted: %d\n", len(cl.Items))
+	var key string
+	var cacheErr error
+	items, report, cached := []CauseListItem(nil), ParseReport{}, false
+	if opts.Cache != nil {
+		if key, err = CacheKey(r, size, opts); err != nil {
+			return err
+		}
+		items, report, cached, cacheErr = cachedRead(opts.Cache, key)
+	}
+	if cached {
+		report.Cached = true
+	} else {
+		report = ParseReport{Format: format.Name(), CacheKey: key}
+		if items, err = format.Read(ctx, cl, r, size, opts, &report); err != nil {
+			return err
+		}
+		if opts.Cache != nil && len(items) > 0 {
+			if err := cacheRead(opts.Cache, key, items, report); err != nil {
+				cacheErr = err
+			}
+		}
+	}
+	if cacheErr != nil {
+		report.CacheError = cacheErr.Error()
+	}
+	cl.Report = report
+	cl.ParserVersion = ParserVersion
+	if cl.ReleaseDate.IsZero() {
+		cl.ReleaseDate = report.Released
+	}
+	if len(items) == 0 {
+		return fmt.Errorf("%w in %s document", ErrNoItemsFound, format.Name())
+	}
+	cl.Items = append(cl.Items, items...)
 	return nil
 }
 
-// parsePageText parses plain text from a page and extracts cause list items
-func (cl *CauseList) parsePageText(text string) []CauseListItem {
-	var items []CauseListItem
+// readerAt returns random access to the file, reading it into memory only
+// when it doesn't already support io.ReaderAt. No more than maxBytes are
+// read into memory when maxBytes is positive.
+func readerAt(file io.Reader, maxBytes int64) (io.ReaderAt, error) {
+	if r, ok := file.(io.ReaderAt); ok {
+		return r, nil
+	}
 
-	// Split text into lines and clean up
+	if maxBytes > 0 {
+		file = io.LimitReader(file, maxBytes+1)
+	}
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return nil, err
+	}
+	if maxBytes > 0 && int64(len(data)) > maxBytes {
+		return nil, fmt.Errorf("%w: more than %d bytes", ErrTooLarge, maxBytes)
+	}
+	return bytes.NewReader(data), nil
+}
+
+// parsePageText parses plain text from a page and extracts cause list items.
+// Items record the page and their lines within the page text as their
+// source, and rows that can't be parsed are recorded in the report against
+// the same.
+func (cl *CauseList) parsePageText(text string, page int, profile *Profile, report *ParseReport) []CauseListItem {
+	// Split text into lines and clean up, remembering where each line came from
 	lines := strings.Split(text, "\n")
 	var cleanLines []string
-	for _, line := range lines {
+	var lineNumbers []int
+	for i, line := range lines {
 		line = strings.TrimSpace(line)
-		if line != "" && !strings.HasPrefix(line, "TNT-") {
+		if line != "" && !profile.isNoise(line) {
 			cleanLines = append(cleanLines, line)
+			lineNumbers = append(lineNumbers, i+1)
 		}
 	}
 
 	// Determine the current section type
-	currentSection := cl.detectSectionType(text)
-	fmt.Printf("Detected section type: %s\n", currentSection)
+	currentSection := profile.detectSectionType(text)
 
 	// The text is formatted with data spread across multiple lines
 	// We need to reconstruct table rows from the scattered text
-	items = cl.reconstructTableRows(cleanLines, currentSection)
+	var rowReport ParseReport
+	items := cl.reconstructTableRows(cleanLines, profile, currentSection, &rowReport)
+	for _, rowErr := range rowReport.RowErrors {
+		report.addRowError(Provenance{Page: page, FirstLine: lineNumbers[rowErr.Line-1]}, rowErr)
+	}
+	for i, item := range items {
+		source := ItemSource(item)
+		if source.FirstLine == 0 {
+			continue // Items of types without provenance
+		}
+		source.Page = page
+		source.FirstLine, source.LastLine = lineNumbers[source.FirstLine-1], lineNumbers[source.LastLine-1]
+		items[i] = withSource(item, source)
+	}
 
 	return items
 }
 
-// reconstructTableRows attempts to reconstruct table rows from fragmented text
-func (cl *CauseList) reconstructTableRows(lines []string, sectionType string) []CauseListItem {
-	var items []CauseListItem
+// matterNumberLinePattern matches the lines of PDF text holding only a
+// matter number, which start the rows of a table
+var matterNumberLinePattern = regexp.MustCompile(`^\d+$`)
 
-	// Look for patterns that indicate the start of a data row (matter numbers)
-	matterNumberPattern := regexp.MustCompile(`^\d+$`)
+// reconstructTableRows attempts to reconstruct table rows from fragmented text.
+// Rows that can't be parsed are recorded with their index in lines, counting from 1.
+func (cl *CauseList) reconstructTableRows(lines []string, profile *Profile, sectionType string, report *ParseReport) []CauseListItem {
+	var items []CauseListItem
 
 	i := 0
 	for i < len(lines) {
 		line := lines[i]
 
 		// Skip header lines
-		if cl.isHeaderLine(line) {
+		if profile.isHeaderLine(line) {
 			i++
 			continue
 		}
 
 		// Check if this line starts with a matter number
-		if matterNumberPattern.MatchString(line) {
+		if matterNumberLinePattern.MatchString(line) {
 			matterNumber, err := strconv.ParseUint(line, 10, 64)
 			if err != nil {
 				i++
@@ -234,10 +343,11 @@ func (cl *CauseList) reconstructTableRows(lines []string, sectionType string) []
 			}
 
 			// Try to extract the full row data starting from this matter number
-			item := cl.extractRowFromPosition(lines, i, matterNumber, sectionType)
-			if item != nil {
+			item, err := cl.extractRowFromPosition(lines, i, matterNumber, profile, sectionType)
+			if err != nil {
+				report.addRowError(Provenance{FirstLine: i + 1}, err)
+			} else if item != nil {
 				items = append(items, item)
-				fmt.Printf("Extracted item with matter number: %d\n", matterNumber)
 			}
 		}
 		i++
@@ -246,27 +356,39 @@ func (cl *CauseList) reconstructTableRows(lines []string, sectionType string) []
 	return items
 }
 
-// extractRowFromPosition extracts a complete row starting from a matter number position
-func (cl *CauseList) extractRowFromPosition(lines []string, startIdx int, matterNumber uint64, sectionType string) CauseListItem {
-	if sectionType == "objection" {
-		return cl.extractObjectionItem(lines, startIdx, matterNumber)
-	} else if sectionType == "forfeiture" {
-		return cl.extractForfeitureItem(lines, startIdx, matterNumber)
-	} else if sectionType == "exemption" {
-		return cl.extractExemptionItem(lines, startIdx, matterNumber)
+// extractRowFromPosition extracts a complete row starting from a matter number
+// position and parses it with the section's parser
+func (cl *CauseList) extractRowFromPosition(lines []string, startIdx int, matterNumber uint64, profile *Profile, sectionType string) (CauseListItem, error) {
+	parser, ok := lookupSectionParser(sectionType)
+	if !ok {
+		return nil, rowError(sectionType, lines[startIdx], "row is not in a recognised section")
 	}
 
-	return nil
+	text, lastIdx := cl.collectRowText(lines, startIdx, matterNumber, profile)
+	row := TextRow{
+		MatterNumber: matterNumber,
+		Text:         text,
+		tenement:     profile.tenement,
+	}
+	item, err := parser.ParseText(row)
+	if err != nil {
+		return nil, asRowError(err, sectionType, row.Text)
+	}
+	return withSource(withParties(item), Provenance{
+		FirstLine: startIdx + 1,
+		LastLine:  lastIdx + 1,
+		Raw:       strings.Join(lines[startIdx:lastIdx+1], "\n"),
+	}), nil
 }
 
-// extractObjectionItem extracts an objection item from the text structure
-func (cl *CauseList) extractObjectionItem(lines []string, startIdx int, matterNumber uint64) CauseListItem {
-	// The text structure from the PDF is:
-	// MATTER_NUMBER OBJECTION_NUMBER OBJECTOR_NAME TENEMENT_NUMBER APPLICANT_NAME
-	// We need to reconstruct this from the fragmented lines
-
+// collectRowText joins the lines of a row, which the PDF text spreads over
+// several lines, e.g. for objections:
+// MATTER_NUMBER OBJECTION_NUMBER OBJECTOR_NAME TENEMENT_NUMBER APPLICANT_NAME
+// It also returns the index of the last line of the row.
+func (cl *CauseList) collectRowText(lines []string, startIdx int, matterNumber uint64, profile *Profile) (string, int) {
 	// Collect all content starting from the matter number line until the next matter number
 	var contentLines []string
+	lastIdx := startIdx
 
 	// Start from the current matter number line and collect subsequent lines
 	for i := startIdx; i < len(lines); i++ {
@@ -276,7 +398,7 @@ func (cl *CauseList) extractObjectionItem(lines []string, startIdx int, matterNu
 		}
 
 		// Stop if we hit the next matter number (but not the current one)
-		if i > startIdx && regexp.MustCompile(`^\d+$`).MatchString(line) && len(line) <= 3 {
+		if i > startIdx && matterNumberLinePattern.MatchString(line) && len(line) <= 3 {
 			// Check if this might be the start of the next record
 			if nextMatter, err := strconv.ParseUint(line, 10, 64); err == nil && nextMatter != matterNumber {
 				break
@@ -284,11 +406,12 @@ func (cl *CauseList) extractObjectionItem(lines []string, startIdx int, matterNu
 		}
 
 		// Stop if we hit headers
-		if cl.isHeaderLine(line) {
+		if profile.isHeaderLine(line) {
 			break
 		}
 
 		contentLines = append(contentLines, line)
+		lastIdx = i
 
 		// Limit how far we look ahead
 		if i > startIdx+15 {
@@ -298,206 +421,69 @@ func (cl *CauseList) extractObjectionItem(lines []string, startIdx int, matterNu
 
 	// Join all content and parse as a single string
 	fullContent := strings.Join(contentLines, " ")
-	fmt.Printf("DEBUG: Full content for matter %d: '%s'\n", matterNumber, fullContent)
-
-	// Parse the content using regex to extract structured data
-	return cl.parseObjectionFromContent(fullContent, matterNumber)
+	return fullContent, lastIdx
 }
 
-// parseObjectionFromContent parses objection data from the combined text content
-func (cl *CauseList) parseObjectionFromContent(content string, matterNumber uint64) CauseListItem {
-	// Expected pattern: MATTER_NUM OBJECTION_NUM OBJECTOR TENEMENT APPLICANT
-	// Use regex to match the pattern
-
-	// Remove the matter number from the beginning
-	content = regexp.MustCompile(`^\d+\s+`).ReplaceAllString(content, "")
-
-	// Extract objection number (first large number)
-	objectionRegex := regexp.MustCompile(`^(\d{6,})\s+`)
-	objectionMatches := objectionRegex.FindStringSubmatch(content)
-	if len(objectionMatches) < 2 {
-		return nil
-	}
-
-	objectionNum, err := strconv.ParseUint(objectionMatches[1], 10, 64)
-	if err != nil {
-		return nil
-	}
-
-	// Remove objection number from content
-	content = objectionRegex.ReplaceAllString(content, "")
-
-	// Find tenement pattern (like "E 15/2082", "L 28/100", etc.)
-	tenementRegex := regexp.MustCompile(`\b([A-Z]+\s+\d+/\d+)\b`)
-	tenementMatches := tenementRegex.FindStringSubmatch(content)
-	if len(tenementMatches) < 2 {
-		return nil
-	}
-
-	tenement := tenementMatches[1]
-	tenementPos := strings.Index(content, tenement)
-
-	// Everything before the tenement is the objector
-	objector := strings.TrimSpace(content[:tenementPos])
-
-	// Everything after the tenement (and tenement itself) contains the applicant
-	afterTenement := content[tenementPos+len(tenement):]
-	applicant := strings.TrimSpace(afterTenement)
-
-	fmt.Printf("DEBUG: Matter %d, Objection %d, Objector: '%s', Tenement: '%s', Applicant: '%s'\n",
-		matterNumber, objectionNum, objector, tenement, applicant)
-
-	return ObjectionItems{
-		CLIItems: CLIItems{
-			MatterNumber:   matterNumber,
-			TenementNumber: tenement,
-			Comments:       "",
-		},
-		ObjectionNumber: objectionNum,
-		ObjectorName:    objector,
-		ApplicantName:   applicant,
-	}
-}
-
-// extractForfeitureItem extracts a forfeiture item (placeholder for now)
-func (cl *CauseList) extractForfeitureItem(lines []string, startIdx int, matterNumber uint64) CauseListItem {
-	// TODO: Implement forfeiture parsing based on actual format
-	return nil
-}
-
-// extractExemptionItem extracts an exemption item (placeholder for now)
-func (cl *CauseList) extractExemptionItem(lines []string, startIdx int, matterNumber uint64) CauseListItem {
-	// TODO: Implement exemption parsing based on actual format
-	return nil
-}
-
-// detectSectionType determines what type of matters are being listed
-func (cl *CauseList) detectSectionType(text string) string {
-	text = strings.ToLower(text)
-
-	if strings.Contains(text, "objection") || strings.Contains(text, "objector") {
-		return "objection"
-	} else if strings.Contains(text, "forfeiture") || strings.Contains(text, "forfeit") {
-		return "forfeiture"
-	} else if strings.Contains(text, "exemption") || strings.Contains(text, "exempt") {
-		return "exemption"
-	}
-
-	return "unknown"
-}
-
-// isHeaderLine checks if a line is a header or section title
-func (cl *CauseList) isHeaderLine(line string) bool {
-	line = strings.ToLower(line)
-
-	headers := []string{
-		"matter number", "objection number", "objector", "tenement affected",
-		"applicant", "comments", "respondent", "exemption",
-	}
-
-	for _, header := range headers {
-		if strings.Contains(line, header) {
-			return true
-		}
-	}
-
-	return false
-}
+// tableFieldSeparatorPattern matches the gaps between the fields of a table row
+var tableFieldSeparatorPattern = regexp.MustCompile(`\s{2,}|\t`)
 
 // parseTableRow attempts to parse a line as a table row and return the appropriate item type
-func (cl *CauseList) parseTableRow(line string, sectionType string) CauseListItem {
+func (cl *CauseList) parseTableRow(line string, profile *Profile, sectionType string) (CauseListItem, error) {
 	// Split the line by common delimiters (tabs, multiple spaces)
-	fields := regexp.MustCompile(`\s{2,}|\t`).Split(line, -1)
+	fields := tableFieldSeparatorPattern.Split(line, -1)
 
 	// Clean up fields
 	for i, field := range fields {
 		fields[i] = strings.TrimSpace(field)
 	}
 
-	// Need at least 3 fields to be a valid row
-	if len(fields) < 3 {
-		return nil
-	}
-
-	// Try to parse the first field as matter number
-	matterNumber, err := strconv.ParseUint(fields[0], 10, 64)
-	if err != nil {
-		return nil // First field must be a matter number
-	}
-
-	switch sectionType {
-	case "objection":
-		return cl.parseObjectionRow(fields, matterNumber)
-	case "forfeiture":
-		return cl.parseForfeitureRow(fields, matterNumber)
-	case "exemption":
-		return cl.parseExemptionRow(fields, matterNumber)
-	default:
-		// Default to objection if section type is unknown
-		return cl.parseObjectionRow(fields, matterNumber)
-	}
+	return cl.parseTableFields(fields, profile, sectionType)
 }
 
-// parseObjectionRow parses a row as an objection item
-// Expected format: matter_number, objection_number, objector, tenement_affected, applicant, comments
-func (cl *CauseList) parseObjectionRow(fields []string, matterNumber uint64) CauseListItem {
-	if len(fields) < 6 {
-		return nil
+// parseTableFields parses the cells of a table row and returns the appropriate
+// item type, reading the cells in the order of the section's columns. Rows
+// that don't have a matter number, such as headers, are not items and return
+// neither an item nor an error.
+func (cl *CauseList) parseTableFields(fields []string, profile *Profile, sectionType string) (CauseListItem, error) {
+	if len(fields) == 0 {
+		return nil, nil
 	}
 
-	objectionNumber, _ := strconv.ParseUint(fields[1], 10, 64)
+	// Default to the profile's first section if section type is unknown
+	section := profile.section(sectionType)
 
-	return ObjectionItems{
-		CLIItems: CLIItems{
-			MatterNumber:   matterNumber,
-			TenementNumber: fields[3], // tenement affected
-			Comments:       fields[5], // comments
-		},
-		ObjectionNumber: objectionNumber,
-		ObjectorName:    fields[2], // objector
-		ApplicantName:   fields[4], // applicant
+	// Try to parse the matter number column. Sections of registered parsers
+	// that have none can't be read from tables.
+	matterColumn := slices.Index(section.Columns, "matter_number")
+	if matterColumn < 0 {
+		return nil, rowError(section.Type, strings.Join(fields, " | "), "section has no matter_number column")
 	}
-}
-
-// parseForfeitureRow parses a row as a forfeiture item
-// Expected format: matter_number, tenement_affected, applicant, respondent, comments
-func (cl *CauseList) parseForfeitureRow(fields []string, matterNumber uint64) CauseListItem {
-	if len(fields) < 5 {
-		return nil
+	matterNumber, err := strconv.ParseUint(getFieldOrEmpty(fields, matterColumn), 10, 64)
+	if err != nil {
+		return nil, nil // Rows must have a matter number
 	}
 
-	return ForfeitureItems{
-		CLIItems: CLIItems{
-			MatterNumber:   matterNumber,
-			TenementNumber: fields[1],                  // tenement affected
-			Comments:       getFieldOrEmpty(fields, 4), // comments
-		},
-		ApplicantName:  fields[2], // applicant
-		RespondentName: fields[3], // respondent
+	// Need at least 3 fields to be a valid row
+	if len(fields) < 3 {
+		return nil, rowError(section.Type, strings.Join(fields, " | "), "expected at least 3 cells, got %d", len(fields))
 	}
-}
 
-// parseExemptionRow parses a row as an exemption item
-// Expected format: matter_number, tenement_affected, applicant, respondent, comments
-func (cl *CauseList) parseExemptionRow(fields []string, matterNumber uint64) CauseListItem {
-	if len(fields) < 4 {
-		return nil
+	cells, err := section.cells(fields)
+	if err != nil {
+		return nil, err
 	}
 
-	return ExemptionItems{
-		CLIItems: CLIItems{
-			MatterNumber:   matterNumber,
-			TenementNumber: fields[1],                  // tenement affected
-			Comments:       getFieldOrEmpty(fields, 4), // comments
-		},
-		ApplicantName:  fields[2],                  // applicant
-		RespondentName: getFieldOrEmpty(fields, 3), // respondent
+	parser, _ := lookupSectionParser(section.Type)
+	item, err := parser.ParseCells(TableRow{MatterNumber: matterNumber, Cells: cells})
+	if err != nil {
+		return nil, asRowError(err, section.Type, strings.Join(fields, " | "))
 	}
+	return item, nil
 }
 
 // getFieldOrEmpty safely gets a field from a slice or returns empty string
 func getFieldOrEmpty(fields []string, index int) string {
-	if index < len(fields) {
+	if index >= 0 && index < len(fields) {
 		return fields[index]
 	}
 	return ""
@@ -1,32 +1,97 @@
 package wclist
 
-//CLIItems represents a cause list item
+import "time"
+
+// CLIItems represents a cause list item
 type CLIItems struct {
-	MatterNumber   uint64
-	TenementNumber string
-	Comments       string
+	MatterNumber   uint64 `json:"matter_number"`
+	TenementNumber string `json:"tenement_number"`
+	Comments       string `json:"comments"`
+	LowConfidence  bool   `json:"low_confidence,omitempty"` // Read by OCR from a scanned page
+
+	// Read from the comments by ParseComments
+	Status      MatterStatus `json:"status,omitempty"`
+	AdjournedTo time.Time    `json:"adjourned_to,omitzero"`
+	HearingDays int          `json:"hearing_days,omitempty"`
+
+	Source Provenance `json:"source,omitzero"` // Where the item was read from
+
+	// parties are parsed from the party fields when the row is parsed. A
+	// pointer keeps items comparable.
+	parties *[]Party
+}
+
+// IsLowConfidence reports whether the item was read by OCR, and so may contain recognition errors
+func (c CLIItems) IsLowConfidence() bool { return c.LowConfidence }
+
+// GetSource returns where the item was read from
+func (c CLIItems) GetSource() Provenance { return c.Source }
+
+// GetStatus returns the status of the matter read from its comments
+func (c CLIItems) GetStatus() MatterStatus { return c.Status }
+
+// GetAdjournedTo returns the date the matter is adjourned to, or the zero time
+func (c CLIItems) GetAdjournedTo() time.Time { return c.AdjournedTo }
+
+// partiesOr returns the parties parsed with the row, or parses them for
+// items built some other way
+func (c CLIItems) partiesOr(parse func() []Party) []Party {
+	if c.parties != nil {
+		return *c.parties
+	}
+	return parse()
+}
+
+// withLowConfidence returns a copy of the item flagged as low confidence
+func withLowConfidence(item CauseListItem) CauseListItem {
+	return updateCLIItems(item, func(c *CLIItems) { c.LowConfidence = true })
 }
 
 // ObjectionItems represents an objection item
 type ObjectionItems struct {
 	CLIItems
-	ObjectionNumber uint64
-	ObjectorName    string
-	ApplicantName   string
+	ObjectionNumber uint64 `json:"objection_number"`
+	ObjectorName    string `json:"objector_name"`
+	ApplicantName   string `json:"applicant_name"`
 }
 
 // ForfeitureItems represents a forfeiture item
 type ForfeitureItems struct {
 	CLIItems
-	ApplicantName  string
-	RespondentName string
+	ApplicantName  string `json:"applicant_name"`
+	RespondentName string `json:"respondent_name"`
 }
 
 // ExemptionItems represents an exemption item
 type ExemptionItems struct {
 	CLIItems
-	ApplicantName  string
-	RespondentName string
+	ApplicantName  string `json:"applicant_name"`
+	RespondentName string `json:"respondent_name"`
+}
+
+// ApplicationItems represents a tenement application, such as for a mining
+// lease, miscellaneous licence or prospecting licence, heard for a
+// recommendation to the Minister
+type ApplicationItems struct {
+	CLIItems
+	ApplicantName   string    `json:"applicant_name"`
+	ApplicationDate time.Time `json:"application_date"` // Zero if the list doesn't give it
+}
+
+// PlaintItems represents a plaint, a civil claim between parties about a tenement
+type PlaintItems struct {
+	CLIItems
+	PlaintiffName string `json:"plaintiff_name"`
+	DefendantName string `json:"defendant_name"`
+	ReliefSought  string `json:"relief_sought"`
+}
+
+// RestorationItems represents an application for restoration of a forfeited
+// tenement or for an extension of time or term
+type RestorationItems struct {
+	CLIItems
+	ApplicantName string `json:"applicant_name"`
+	ReliefSought  string `json:"relief_sought"`
 }
 
 // Implement the CauseListItem interface for ObjectionItems
@@ -35,6 +100,10 @@ func (c ObjectionItems) GetTenementNumber() string  { return c.TenementNumber }
 func (c ObjectionItems) GetComments() string        { return c.Comments }
 func (c ObjectionItems) GetApplyingParty() string   { return c.ApplicantName }
 func (c ObjectionItems) GetRespondingParty() string { return c.ObjectorName }
+func (c ObjectionItems) GetParties() []Party        { return c.partiesOr(c.parseParties) }
+func (c ObjectionItems) parseParties() []Party {
+	return append(ParseParties(c.ApplicantName, RoleApplicant), ParseParties(c.ObjectorName, RoleObjector)...)
+}
 
 // Additional method specific to ObjectionItems
 func (c ObjectionItems) GetObjectionNumber() uint64 { return c.ObjectionNumber }
@@ -45,6 +114,10 @@ func (f ForfeitureItems) GetTenementNumber() string  { return f.TenementNumber }
 func (f ForfeitureItems) GetComments() string        { return f.Comments }
 func (f ForfeitureItems) GetApplyingParty() string   { return f.ApplicantName }
 func (f ForfeitureItems) GetRespondingParty() string { return f.RespondentName }
+func (f ForfeitureItems) GetParties() []Party        { return f.partiesOr(f.parseParties) }
+func (f ForfeitureItems) parseParties() []Party {
+	return append(ParseParties(f.ApplicantName, RoleApplicant), ParseParties(f.RespondentName, RoleRespondent)...)
+}
 
 // Implement the CauseListItem interface for ExemptionItems
 func (e ExemptionItems) GetMatterNumber() uint64    { return e.MatterNumber }
@@ -52,3 +125,36 @@ func (e ExemptionItems) GetTenementNumber() string  { return e.TenementNumber }
 func (e ExemptionItems) GetComments() string        { return e.Comments }
 func (e ExemptionItems) GetApplyingParty() string   { return e.ApplicantName }
 func (e ExemptionItems) GetRespondingParty() string { return e.RespondentName }
+func (e ExemptionItems) GetParties() []Party        { return e.partiesOr(e.parseParties) }
+func (e ExemptionItems) parseParties() []Party {
+	return append(ParseParties(e.ApplicantName, RoleApplicant), ParseParties(e.RespondentName, RoleRespondent)...)
+}
+
+// Implement the CauseListItem interface for ApplicationItems
+func (a ApplicationItems) GetMatterNumber() uint64    { return a.MatterNumber }
+func (a ApplicationItems) GetTenementNumber() string  { return a.TenementNumber }
+func (a ApplicationItems) GetComments() string        { return a.Comments }
+func (a ApplicationItems) GetApplyingParty() string   { return a.ApplicantName }
+func (a ApplicationItems) GetRespondingParty() string { return "" }
+func (a ApplicationItems) GetParties() []Party        { return a.partiesOr(a.parseParties) }
+func (a ApplicationItems) parseParties() []Party      { return ParseParties(a.ApplicantName, RoleApplicant) }
+
+// Implement the CauseListItem interface for PlaintItems
+func (p PlaintItems) GetMatterNumber() uint64    { return p.MatterNumber }
+func (p PlaintItems) GetTenementNumber() string  { return p.TenementNumber }
+func (p PlaintItems) GetComments() string        { return p.Comments }
+func (p PlaintItems) GetApplyingParty() string   { return p.PlaintiffName }
+func (p PlaintItems) GetRespondingParty() string { return p.DefendantName }
+func (p PlaintItems) GetParties() []Party        { return p.partiesOr(p.parseParties) }
+func (p PlaintItems) parseParties() []Party {
+	return append(ParseParties(p.PlaintiffName, RolePlaintiff), ParseParties(p.DefendantName, RoleDefendant)...)
+}
+
+// Implement the CauseListItem interface for RestorationItems
+func (r RestorationItems) GetMatterNumber() uint64    { return r.MatterNumber }
+func (r RestorationItems) GetTenementNumber() string  { return r.TenementNumber }
+func (r RestorationItems) GetComments() string        { return r.Comments }
+func (r RestorationItems) GetApplyingParty() string   { return r.ApplicantName }
+func (r RestorationItems) GetRespondingParty() string { return "" }
+func (r RestorationItems) GetParties() []Party        { return r.partiesOr(r.parseParties) }
+func (r RestorationItems) parseParties() []Party      { return ParseParties(r.ApplicantName, RoleApplicant) }
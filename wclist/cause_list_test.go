@@ -1,7 +1,12 @@
 package wclist
 
 import (
+	"bytes"
+	"context"
+	"errors"
 	"os"
+	"reflect"
+	"runtime"
 	"testing"
 	"time"
 )
@@ -25,3 +30,123 @@ func TestCauseList(t *testing.T) {
 		}
 	})
 }
+
+func TestReadCauseListContext(t *testing.T) {
+	data, err := os.ReadFile("../test/test.pdf")
+	if err != nil {
+		t.Fatalf("Failed to read test file: %v", err)
+	}
+
+	read := func(ctx context.Context) (*CauseList, error) {
+		cl := NewCauseList("Warden's Court", "Kalgoorlie", time.Now())
+		err := cl.ReadCauseListContext(ctx, bytes.NewReader(data), int64(len(data)))
+		return cl, err
+	}
+
+	t.Run("Deterministic order", func(t *testing.T) {
+		// A single worker parses the pages one after another
+		procs := runtime.GOMAXPROCS(1)
+		sequential, err := read(context.Background())
+		runtime.GOMAXPROCS(procs)
+		if err != nil {
+			t.Fatalf("Failed to read cause list: %v", err)
+		}
+		if len(sequential.Items) == 0 {
+			t.Fatal("Expected items to be extracted")
+		}
+
+		for i := 0; i < 3; i++ {
+			concurrent, err := read(context.Background())
+			if err != nil {
+				t.Fatalf("Failed to read cause list: %v", err)
+			}
+			if !reflect.DeepEqual(concurrent.Items, sequential.Items) {
+				t.Fatal("Expected concurrent reads to return items in page and row order")
+			}
+		}
+	})
+
+	t.Run("Cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		cl, err := read(ctx)
+		if !errors.Is(err, context.Canceled) {
+			t.Fatalf("Expected context.Canceled, got %v", err)
+		}
+		if len(cl.Items) != 0 {
+			t.Fatalf("Expected no items after cancellation, got %d", len(cl.Items))
+		}
+	})
+}
+
+func TestStreamItems(t *testing.T) {
+	file, err := os.Open("../test/test.pdf")
+	if err != nil {
+		t.Fatalf("Failed to open test file: %v", err)
+	}
+	defer file.Close()
+	stat, err := file.Stat()
+	if err != nil {
+		t.Fatalf("Failed to get file info: %v", err)
+	}
+
+	cl := NewCauseList("Warden's Court", "Kalgoorlie", time.Now())
+	if err := cl.ReadCauseList(file, stat.Size()); err != nil {
+		t.Fatalf("Failed to read cause list: %v", err)
+	}
+
+	t.Run("Matches ReadCauseList", func(t *testing.T) {
+		var streamed []CauseListItem
+		for item, err := range cl.StreamItems(file, stat.Size()) {
+			if err != nil {
+				t.Fatalf("Failed to stream items: %v", err)
+			}
+			streamed = append(streamed, item)
+		}
+		if !reflect.DeepEqual(streamed, cl.Items) {
+			t.Fatalf("Streamed %d items, ReadCauseList returned %d", len(streamed), len(cl.Items))
+		}
+	})
+
+	t.Run("Stops early", func(t *testing.T) {
+		count := 0
+		for range cl.StreamItems(file, stat.Size()) {
+			count++
+			if count == 3 {
+				break
+			}
+		}
+		if count != 3 {
+			t.Fatalf("Expected to stop after 3 items, got %d", count)
+		}
+	})
+
+	t.Run("Cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		count := 0
+		var errs []error
+		for _, err := range cl.StreamItemsOptions(ctx, file, stat.Size(), unlimitedReadOptions()) {
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			if count++; count == 3 {
+				cancel()
+			}
+		}
+		if len(errs) != 1 || !errors.Is(errs[0], context.Canceled) || count >= len(cl.Items) {
+			t.Fatalf("Expected the stream to end with context.Canceled, got %v after %d items", errs, count)
+		}
+	})
+
+	t.Run("Invalid document", func(t *testing.T) {
+		data := []byte("not a cause list")
+		for item, err := range cl.StreamItems(bytes.NewReader(data), int64(len(data))) {
+			if err == nil || item != nil {
+				t.Fatalf("Expected only an error, got %v, %v", item, err)
+			}
+		}
+	})
+}
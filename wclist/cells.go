@@ -0,0 +1,91 @@
+package wclist
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// ItemFromCells builds an item of a matter type from cells named by the
+// type's columns, e.g. "objector" and "tenement" for objections, as when a
+// reviewer corrects a row by hand. The matter_number cell is required and
+// missing optional cells are empty. The item records source as where it
+// was read from.
+func ItemFromCells(sectionType string, cells map[string]string, source Provenance) (CauseListItem, error) {
+	parser, ok := lookupSectionParser(sectionType)
+	if !ok {
+		return nil, fmt.Errorf("unknown matter type %q", sectionType)
+	}
+
+	columns := make([]string, len(parser.Columns()))
+	for i, column := range parser.Columns() {
+		columns[i] = strings.TrimSuffix(column, "?")
+	}
+	row := TableRow{Cells: make(map[string]string, len(columns))}
+	for column, value := range cells {
+		if !slices.Contains(columns, column) {
+			return nil, fmt.Errorf("%s has no column %q, expected one of %s", sectionType, column, strings.Join(columns, ", "))
+		}
+		row.Cells[column] = strings.TrimSpace(value)
+	}
+
+	matter, err := strconv.ParseUint(row.Cells["matter_number"], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("matter_number %q is not a number", row.Cells["matter_number"])
+	}
+	row.MatterNumber = matter
+
+	item, err := parser.ParseCells(row)
+	if err != nil {
+		return nil, err
+	}
+	return withSource(withParties(item), source), nil
+}
+
+// ItemCells returns the cells of an item, named by the columns of its
+// type, so that it can be corrected and rebuilt with ItemFromCells. Items of
+// registered types give only their matter number, tenement and comments.
+func ItemCells(item CauseListItem) map[string]string {
+	cells := map[string]string{
+		"matter_number": strconv.FormatUint(item.GetMatterNumber(), 10),
+		"tenement":      item.GetTenementNumber(),
+		"comments":      item.GetComments(),
+	}
+
+	switch item := item.(type) {
+	case ObjectionItems:
+		cells["objection_number"] = strconv.FormatUint(item.ObjectionNumber, 10)
+		cells["objector"] = item.ObjectorName
+		cells["applicant"] = item.ApplicantName
+	case ForfeitureItems:
+		cells["applicant"] = item.ApplicantName
+		cells["respondent"] = item.RespondentName
+	case ExemptionItems:
+		cells["applicant"] = item.ApplicantName
+		cells["respondent"] = item.RespondentName
+	case ApplicationItems:
+		cells["applicant"] = item.ApplicantName
+		cells["application_date"] = ""
+		if !item.ApplicationDate.IsZero() {
+			cells["application_date"] = item.ApplicationDate.Format("02/01/2006")
+		}
+	case PlaintItems:
+		cells["plaintiff"] = item.PlaintiffName
+		cells["defendant"] = item.DefendantName
+		cells["relief_sought"] = item.ReliefSought
+	case RestorationItems:
+		cells["applicant"] = item.ApplicantName
+		cells["relief_sought"] = item.ReliefSought
+	}
+
+	// Registered types may not have every common column
+	if parser, ok := lookupSectionParser(ItemType(item)); ok {
+		for column := range cells {
+			if !slices.Contains(parser.Columns(), column) && !slices.Contains(parser.Columns(), column+"?") {
+				delete(cells, column)
+			}
+		}
+	}
+	return cells
+}
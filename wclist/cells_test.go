@@ -0,0 +1,76 @@
+package wclist
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestItemFromCells(t *testing.T) {
+	source := Provenance{Page: 2, FirstLine: 20, LastLine: 20, Raw: "698561 KARORA (HIGGINSVILLE) PTY LTD E 15/2082 FMG RESOURCES PTY LTD"}
+
+	t.Run("Objection", func(t *testing.T) {
+		item, err := ItemFromCells("objection", map[string]string{
+			"matter_number":    "1",
+			"objection_number": "698561",
+			"objector":         " KARORA (HIGGINSVILLE) PTY LTD ",
+			"tenement":         "E 15/2082",
+			"applicant":        "FMG RESOURCES PTY LTD",
+		}, source)
+		if err != nil {
+			t.Fatalf("Failed to build item: %v", err)
+		}
+		objection, ok := item.(ObjectionItems)
+		if !ok {
+			t.Fatalf("Expected an objection, got %T", item)
+		}
+		if objection.MatterNumber != 1 || objection.ObjectionNumber != 698561 ||
+			objection.ObjectorName != "KARORA (HIGGINSVILLE) PTY LTD" || objection.TenementNumber != "E 15/2082" {
+			t.Fatalf("Unexpected item %+v", objection)
+		}
+		if got := ItemSource(item); got.Page != 2 || got.FirstLine != 20 || got.Raw != source.Raw {
+			t.Fatalf("Expected the item to keep its source, got %+v", got)
+		}
+	})
+
+	t.Run("Round trip", func(t *testing.T) {
+		item := ForfeitureItems{
+			CLIItems:       CLIItems{MatterNumber: 2, TenementNumber: "P 15/6895-S", Comments: "Adjourned"},
+			ApplicantName:  "HIGGINS, Ryan",
+			RespondentName: "DYNAMIC METALS LIMITED",
+		}
+		cells := ItemCells(item)
+		if cells["respondent"] != "DYNAMIC METALS LIMITED" || cells["matter_number"] != "2" {
+			t.Fatalf("Unexpected cells %v", cells)
+		}
+		rebuilt, err := ItemFromCells(ItemType(item), cells, Provenance{})
+		if err != nil {
+			t.Fatalf("Failed to rebuild item: %v", err)
+		}
+		forfeiture := rebuilt.(ForfeitureItems)
+		if forfeiture.ApplicantName != item.ApplicantName || forfeiture.RespondentName != item.RespondentName ||
+			forfeiture.TenementNumber != item.TenementNumber || forfeiture.Comments != item.Comments {
+			t.Fatalf("Expected %+v, got %+v", item, forfeiture)
+		}
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		tests := []struct {
+			name    string
+			section string
+			cells   map[string]string
+			err     string
+		}{
+			{"Unknown type", "appeal", map[string]string{"matter_number": "1"}, `unknown matter type "appeal"`},
+			{"Unknown column", "objection", map[string]string{"matter_number": "1", "respondent": "X"}, `objection has no column "respondent"`},
+			{"Missing matter number", "objection", map[string]string{"objector": "X"}, `matter_number "" is not a number`},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				_, err := ItemFromCells(tt.section, tt.cells, source)
+				if err == nil || !strings.Contains(err.Error(), tt.err) {
+					t.Fatalf("Expected error %q, got %v", tt.err, err)
+				}
+			})
+		}
+	})
+}
@@ -0,0 +1,111 @@
+package wclist
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// MatterStatus is the outcome or listing of a matter, read from its comments
+type MatterStatus string
+
+// Statuses read from the comments of a matter. Items without comments have
+// no status, and comments that aren't recognised give StatusOther.
+const (
+	StatusWithdrawn        MatterStatus = "withdrawn"
+	StatusVacated          MatterStatus = "vacated"
+	StatusDismissed        MatterStatus = "dismissed"
+	StatusConsentOrders    MatterStatus = "consent_orders"
+	StatusAdjourned        MatterStatus = "adjourned"
+	StatusListedForHearing MatterStatus = "listed_for_hearing"
+	StatusInChambers       MatterStatus = "in_chambers"
+	StatusOther            MatterStatus = "other"
+)
+
+// commentStatuses are checked in order, so that the final outcome of a matter
+// wins over how it was listed, e.g. "Adjourned, now withdrawn" is withdrawn
+var commentStatuses = []struct {
+	status  MatterStatus
+	pattern *regexp.Regexp
+}{
+	{StatusWithdrawn, regexp.MustCompile(`(?i)\bwithdra(wn|wal)\b`)},
+	{StatusVacated, regexp.MustCompile(`(?i)\bvacated\b`)},
+	{StatusDismissed, regexp.MustCompile(`(?i)\b(dismissed|struck out)\b`)},
+	{StatusConsentOrders, regexp.MustCompile(`(?i)\b(consent orders?|by consent)\b`)},
+	{StatusAdjourned, regexp.MustCompile(`(?i)\badjourn(ed|ment)?\b`)},
+	{StatusListedForHearing, regexp.MustCompile(`(?i)\bhearing\b`)},
+	{StatusInChambers, regexp.MustCompile(`(?i)\bin chambers\b`)},
+}
+
+var (
+	// commentStartPattern matches the start of the comments in a row of PDF
+	// text, which follow the last party without a break
+	commentStartPattern = regexp.MustCompile(`(?i)\b(withdrawn|withdrawal|vacated|dismissed|struck out|consent orders?|by consent|adjourned|adjournment|listed for hearing|in chambers|part heard)\b`)
+	// adjournedToPattern matches the date a matter is adjourned to
+	adjournedToPattern = regexp.MustCompile(`(?i)\badjourn(?:ed|ment)?\s+(?:to|until|till)\s+(\d{1,2}/\d{1,2}/\d{4}|\d{1,2}\s+[a-z]+\s+\d{4})`)
+	// hearingDaysPattern matches the length of a hearing, e.g. "hearing 2
+	// days" or "2 day hearing"
+	hearingDaysPattern = regexp.MustCompile(`(?i)\bhearing\b[^\d.;]{0,20}?\b(\d+)\s+days?\b|\b(\d+)\s+days?\s+hearing\b`)
+)
+
+// CommentDetails is the information read from the comments of a matter
+type CommentDetails struct {
+	Status      MatterStatus
+	AdjournedTo time.Time // Zero unless the comments give the date
+	HearingDays int       // Zero unless the comments give the length of the hearing
+}
+
+// ParseComments reads the status, adjournment date and hearing length from
+// the comments of a matter, e.g. "Adjourned to 12/11/2025" or "Listed for
+// hearing 2 days"
+func ParseComments(comments string) CommentDetails {
+	var details CommentDetails
+	comments = strings.TrimSpace(comments)
+	if comments == "" {
+		return details
+	}
+
+	details.Status = StatusOther
+	for _, s := range commentStatuses {
+		if s.pattern.MatchString(comments) {
+			details.Status = s.status
+			break
+		}
+	}
+
+	if match := adjournedToPattern.FindStringSubmatch(comments); match != nil {
+		if date, err := parseListDate(match[1]); err == nil {
+			details.AdjournedTo = date
+		}
+	}
+	if match := hearingDaysPattern.FindStringSubmatch(comments); match != nil {
+		details.HearingDays, _ = strconv.Atoi(match[1] + match[2])
+	}
+
+	return details
+}
+
+// newCLIItems creates the fields common to every item, reading the
+// structured details from the comments
+func newCLIItems(matterNumber uint64, tenement, comments string) CLIItems {
+	details := ParseComments(comments)
+	return CLIItems{
+		MatterNumber:   matterNumber,
+		TenementNumber: tenement,
+		Comments:       comments,
+		Status:         details.Status,
+		AdjournedTo:    details.AdjournedTo,
+		HearingDays:    details.HearingDays,
+	}
+}
+
+// splitComments splits the comments from the end of the text of a row,
+// returning the text before them and the comments
+func splitComments(text string) (string, string) {
+	loc := commentStartPattern.FindStringIndex(text)
+	if loc == nil {
+		return strings.TrimSpace(text), ""
+	}
+	return strings.TrimSpace(text[:loc[0]]), strings.TrimSpace(text[loc[0]:])
+}
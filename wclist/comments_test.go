@@ -0,0 +1,54 @@
+package wclist
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseComments(t *testing.T) {
+	tests := []struct {
+		comments string
+		want     CommentDetails
+	}{
+		{"", CommentDetails{}},
+		{"Withdrawn", CommentDetails{Status: StatusWithdrawn}},
+		{"Adjourned to 12/11/2025", CommentDetails{Status: StatusAdjourned, AdjournedTo: time.Date(2025, 11, 12, 0, 0, 0, 0, time.UTC)}},
+		{"adjourned until 3 February 2026", CommentDetails{Status: StatusAdjourned, AdjournedTo: time.Date(2026, 2, 3, 0, 0, 0, 0, time.UTC)}},
+		{"Consent orders", CommentDetails{Status: StatusConsentOrders}},
+		{"Listed for hearing 2 days", CommentDetails{Status: StatusListedForHearing, HearingDays: 2}},
+		{"3 day hearing", CommentDetails{Status: StatusListedForHearing, HearingDays: 3}},
+		{"Recommend grant, lodged 30 days late", CommentDetails{Status: StatusOther}},
+		{"Listed for hearing. Report due in 14 days", CommentDetails{Status: StatusListedForHearing}},
+		{"Vacated", CommentDetails{Status: StatusVacated}},
+		{"In Chambers", CommentDetails{Status: StatusInChambers}},
+		{"Adjourned, now withdrawn", CommentDetails{Status: StatusWithdrawn}},
+		{"Recommend grant", CommentDetails{Status: StatusOther}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.comments, func(t *testing.T) {
+			if got := ParseComments(tt.comments); got != tt.want {
+				t.Fatalf("Expected %+v, got %+v", tt.want, got)
+			}
+		})
+	}
+
+	t.Run("PDF text", func(t *testing.T) {
+		row := TextRow{
+			MatterNumber: 63,
+			Text:         "63 712980 BEACON MINERALS LIMITED E 15/2082 HIGGINS, Ryan Adjourned to 12/11/2025",
+			tenement:     DefaultProfile().tenement,
+		}
+		item, err := objectionParser{}.ParseText(row)
+		if err != nil {
+			t.Fatalf("Failed to parse objection row: %v", err)
+		}
+		objItem := item.(ObjectionItems)
+		if objItem.Comments != "Adjourned to 12/11/2025" || objItem.ApplicantName != "HIGGINS, Ryan" {
+			t.Fatalf("Expected the comments to be split from the applicant, got %+v", objItem)
+		}
+		if objItem.Status != StatusAdjourned || objItem.AdjournedTo != time.Date(2025, 11, 12, 0, 0, 0, 0, time.UTC) {
+			t.Fatalf("Expected the adjournment to be parsed, got %+v", objItem.CLIItems)
+		}
+	})
+}
@@ -0,0 +1,59 @@
+package wclist
+
+import "slices"
+
+// Kinds of ItemChange
+const (
+	ItemAdded   = "added"
+	ItemRemoved = "removed"
+	ItemChanged = "changed"
+)
+
+// ItemChange is an item that reading a document again added, removed or
+// changed. Items are compared by their fields as in Evaluate.
+type ItemChange struct {
+	Change string       `json:"change"`
+	Matter string       `json:"matter"`
+	Before LabelledItem `json:"before,omitempty"` // Empty for added items
+	After  LabelledItem `json:"after,omitempty"`  // Empty for removed items
+	Fields []string     `json:"fields,omitempty"` // Fields of a changed item that differ
+}
+
+// CompareItems returns the changes from one reading of a document to
+// another. Items are paired by matter number, and where a matter has
+// several items by the pair that agree on the most fields; items that are
+// the same in both aren't reported. Changes are in the order of the items
+// before, followed by the items added.
+func CompareItems(before, after []CauseListItem) []ItemChange {
+	old, current := labelItems(before), labelItems(after)
+	pairs := pairRows(old, current)
+	paired := make([]bool, len(current))
+
+	var changes []ItemChange
+	for j, label := range old {
+		matter := normalizeLabel(label["matter"])
+		if pairs[j] < 0 {
+			changes = append(changes, ItemChange{Change: ItemRemoved, Matter: matter, Before: label})
+			continue
+		}
+		paired[pairs[j]] = true
+
+		var fields []string
+		for _, field := range ItemFields {
+			name := field.Name
+			if !slices.Contains(derivedFields, name) && normalizeLabel(label[name]) != normalizeLabel(current[pairs[j]][name]) {
+				fields = append(fields, name)
+			}
+		}
+		if len(fields) > 0 {
+			changes = append(changes, ItemChange{Change: ItemChanged, Matter: matter, Before: label, After: current[pairs[j]], Fields: fields})
+		}
+	}
+
+	for i, label := range current {
+		if !paired[i] {
+			changes = append(changes, ItemChange{Change: ItemAdded, Matter: normalizeLabel(label["matter"]), After: label})
+		}
+	}
+	return changes
+}
@@ -0,0 +1,52 @@
+package wclist
+
+import (
+	"bytes"
+	"slices"
+	"testing"
+)
+
+func TestCompareItems(t *testing.T) {
+	before := []CauseListItem{
+		ObjectionItems{CLIItems: CLIItems{MatterNumber: 1, TenementNumber: "E 15/2082"}, ObjectionNumber: 698561,
+			ObjectorName: "KARORA (HIGGINSVILLE)", ApplicantName: "PTY LTD FMG RESOURCES PTY LTD"},
+		ObjectionItems{CLIItems: CLIItems{MatterNumber: 2, TenementNumber: "E 15/2098"}, ObjectionNumber: 712980,
+			ObjectorName: "BEACON MINERALS LIMITED", ApplicantName: "WEST AUSTRALIAN PROSPECTORS PTY LTD"},
+		ObjectionItems{CLIItems: CLIItems{MatterNumber: 698562}, ObjectorName: "KARORA"},
+	}
+	after := []CauseListItem{
+		before[1],
+		ObjectionItems{CLIItems: CLIItems{MatterNumber: 1, TenementNumber: "E 15/2082"}, ObjectionNumber: 698561,
+			ObjectorName: "KARORA (HIGGINSVILLE) PTY LTD", ApplicantName: "FMG RESOURCES PTY LTD"},
+		ForfeitureItems{CLIItems: CLIItems{MatterNumber: 3, TenementNumber: "E 16/396"},
+			ApplicantName: "ASHCROFT, Sean Cameron", RespondentName: "GOLD TIGER HOLDINGS (AUSTRALIA) PTY LTD"},
+	}
+
+	changes := CompareItems(before, after)
+	if len(changes) != 3 {
+		t.Fatalf("Expected 3 changes, got %+v", changes)
+	}
+	if c := changes[0]; c.Change != ItemChanged || c.Matter != "1" || !slices.Equal(c.Fields, []string{"objector", "applicant"}) {
+		t.Fatalf("Expected the parties of matter 1 to change, got %+v", c)
+	}
+	if c := changes[1]; c.Change != ItemRemoved || c.Matter != "698562" || c.After != nil {
+		t.Fatalf("Expected the spurious row to be removed, got %+v", c)
+	}
+	if c := changes[2]; c.Change != ItemAdded || c.Matter != "3" || c.After["type"] != "forfeiture" {
+		t.Fatalf("Expected matter 3 to be added, got %+v", c)
+	}
+
+	t.Run("Reread", func(t *testing.T) {
+		var buf bytes.Buffer
+		if _, err := GenerateCauseList(&buf, GenerateOptions{Seed: 3, Sections: []string{"objection"}}); err != nil {
+			t.Fatalf("Failed to generate cause list: %v", err)
+		}
+		first, second := readGenerated(t, buf.Bytes()), readGenerated(t, buf.Bytes())
+		if first.ParserVersion != ParserVersion {
+			t.Fatalf("Expected the list to record parser version %d, got %d", ParserVersion, first.ParserVersion)
+		}
+		if changes := CompareItems(first.Items, second.Items); len(changes) != 0 {
+			t.Fatalf("Expected reading the same document again to change nothing, got %+v", changes)
+		}
+	})
+}
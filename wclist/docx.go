@@ -0,0 +1,152 @@
+package wclist
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// docxSource reads cause lists published as Word documents
+type docxSource struct{}
+
+func (docxSource) Name() string { return "docx" }
+
+func (docxSource) Detect(r io.ReaderAt, size int64) bool {
+	magic := make([]byte, 4)
+	if n, _ := r.ReadAt(magic, 0); n < len(magic) || !bytes.Equal(magic, []byte("PK\x03\x04")) {
+		return false
+	}
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		return false
+	}
+	return docxDocument(zr) != nil
+}
+
+func (docxSource) Read(ctx context.Context, cl *CauseList, r io.ReaderAt, size int64, opts ReadOptions, report *ParseReport) ([]CauseListItem, error) {
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, err
+	}
+	document := docxDocument(zr)
+	if document == nil {
+		return nil, fmt.Errorf("word document has no word/document.xml")
+	}
+
+	rc, err := document.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+
+	tables, err := docxTables(rc)
+	if err != nil {
+		return nil, fmt.Errorf("reading word document: %w", err)
+	}
+	return cl.itemsFromTables(ctx, tables, opts, report)
+}
+
+// docxDocument returns the main document part of a DOCX package
+func docxDocument(zr *zip.Reader) *zip.File {
+	for _, f := range zr.File {
+		if f.Name == "word/document.xml" {
+			return f
+		}
+	}
+	return nil
+}
+
+// docxTables collects the tables in a WordprocessingML document along with
+// the text of the paragraph closest before each one. Paragraphs within a
+// cell are joined with spaces.
+func docxTables(r io.Reader) ([]sourceTable, error) {
+	var (
+		tables    []sourceTable
+		heading   string
+		preamble  []string
+		paragraph strings.Builder
+		cell      strings.Builder
+		row       []string
+		table     *sourceTable
+		depth     int // Nesting depth of tables; nested tables are flattened into their cell
+		inText    bool
+	)
+
+	decoder := xml.NewDecoder(r)
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			switch t.Name.Local {
+			case "tbl":
+				depth++
+				if depth == 1 {
+					table = &sourceTable{Heading: heading, Preamble: strings.Join(preamble, "\n")}
+					preamble = nil
+				}
+			case "tr":
+				if depth == 1 {
+					row = nil
+				}
+			case "tc":
+				if depth == 1 {
+					cell.Reset()
+				}
+			case "p":
+				paragraph.Reset()
+			case "t":
+				inText = true
+			case "tab", "br":
+				paragraph.WriteString(" ")
+			}
+
+		case xml.CharData:
+			if inText {
+				paragraph.Write(t)
+			}
+
+		case xml.EndElement:
+			switch t.Name.Local {
+			case "t":
+				inText = false
+			case "p":
+				text := cleanCellText(paragraph.String())
+				if depth == 0 {
+					if text != "" {
+						heading = text
+						preamble = append(preamble, text)
+					}
+				} else if text != "" {
+					cell.WriteString(text + " ")
+				}
+			case "tc":
+				if depth == 1 {
+					row = append(row, cleanCellText(cell.String()))
+				}
+			case "tr":
+				if depth == 1 && table != nil {
+					table.Rows = append(table.Rows, row)
+				}
+			case "tbl":
+				depth--
+				if depth == 0 && table != nil {
+					tables = append(tables, *table)
+					table = nil
+				}
+			}
+		}
+	}
+
+	return tables, nil
+}
@@ -0,0 +1,76 @@
+package wclist
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrMalformedPDF reports a PDF that can't be parsed safely. The more
+// specific errors below all match it with errors.Is.
+var ErrMalformedPDF = errors.New("wclist: malformed PDF")
+
+var (
+	// ErrTooManyPages reports a PDF with more pages than ReadOptions.MaxPages
+	ErrTooManyPages = &familyError{ErrMalformedPDF, "document has too many pages"}
+	// ErrPageTimeout reports a page that took longer than ReadOptions.PageTimeout
+	ErrPageTimeout = &familyError{ErrMalformedPDF, "page took too long to parse"}
+	// ErrPagePanic reports a page that made the PDF reader panic
+	ErrPagePanic = &familyError{ErrMalformedPDF, "page could not be parsed"}
+)
+
+// ErrTooLarge reports a document larger than ReadOptions.MaxBytes
+var ErrTooLarge = errors.New("wclist: document is too large")
+
+// familyError is a more specific form of another sentinel error, which it
+// matches with errors.Is
+type familyError struct {
+	parent error
+	msg    string
+}
+
+func (e *familyError) Error() string { return e.parent.Error() + ": " + e.msg }
+
+func (e *familyError) Is(target error) bool { return target == e.parent }
+
+var (
+	// ErrNotACauseList reports a document that isn't in a recognised cause list format
+	ErrNotACauseList = errors.New("wclist: not a cause list")
+	// ErrEncryptedPDF reports a PDF that can't be read without a password,
+	// or that uses encryption the PDF reader doesn't support
+	ErrEncryptedPDF = errors.New("wclist: PDF is encrypted")
+	// ErrWrongPassword reports a password that doesn't open an encrypted PDF.
+	// It also matches ErrEncryptedPDF.
+	ErrWrongPassword = &familyError{ErrEncryptedPDF, "wrong password"}
+	// ErrNoItemsFound reports a document that was read but contained no items
+	ErrNoItemsFound = errors.New("wclist: no items found")
+	// ErrScannedPage reports an image-only page that couldn't be read by OCR
+	ErrScannedPage = errors.New("wclist: page is a scanned image")
+)
+
+// RowParseError is a row that looked like a cause list item, because it
+// starts with a matter number, but couldn't be turned into one. Page is 0
+// for formats without pages, where Table is the table the row is in,
+// counting from 1, and Line is the row within it.
+type RowParseError struct {
+	Page    int    `json:"page"`
+	Table   int    `json:"table,omitempty"`
+	Line    int    `json:"line"`
+	Section string `json:"section"`
+	Raw     string `json:"raw"`
+	Reason  string `json:"reason"`
+}
+
+func (e RowParseError) Error() string {
+	if e.Table > 0 {
+		return fmt.Sprintf("table %d row %d (%s): %s: %q", e.Table, e.Line, e.Section, e.Reason, e.Raw)
+	}
+	if e.Page == 0 {
+		return fmt.Sprintf("row %d (%s): %s: %q", e.Line, e.Section, e.Reason, e.Raw)
+	}
+	return fmt.Sprintf("page %d line %d (%s): %s: %q", e.Page, e.Line, e.Section, e.Reason, e.Raw)
+}
+
+// rowError reports a row that couldn't be parsed. The caller fills in where it was found.
+func rowError(section, raw, format string, args ...interface{}) error {
+	return RowParseError{Section: section, Raw: raw, Reason: fmt.Sprintf(format, args...)}
+}
@@ -0,0 +1,53 @@
+package wclist
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestReadErrors(t *testing.T) {
+	read := func(document string) (*CauseList, error) {
+		cl := NewCauseList("Western Australia", "Kalgoorlie", time.Now())
+		err := cl.ReadCauseList(bytes.NewReader([]byte(document)), int64(len(document)))
+		return cl, err
+	}
+
+	t.Run("not a cause list", func(t *testing.T) {
+		if _, err := read("just some plain text"); !errors.Is(err, ErrNotACauseList) {
+			t.Fatalf("Expected ErrNotACauseList, got %v", err)
+		}
+	})
+
+	t.Run("no items found", func(t *testing.T) {
+		if _, err := read("<html><body><p>No sittings listed</p></body></html>"); !errors.Is(err, ErrNoItemsFound) {
+			t.Fatalf("Expected ErrNoItemsFound, got %v", err)
+		}
+	})
+
+	t.Run("row errors", func(t *testing.T) {
+		// The second objection row is missing its applicant and comments
+		document := strings.Replace(htmlCauseList,
+			`<td>WEST AUSTRALIAN PROSPECTORS PTY LTD</td><td>Adjourned</td>`, ``, 1)
+		cl, err := read(document)
+		if err != nil {
+			t.Fatalf("Failed to read cause list: %v", err)
+		}
+		if len(cl.Items) != 2 {
+			t.Fatalf("Expected the 2 good rows to be read, got %d items", len(cl.Items))
+		}
+
+		if len(cl.Report.RowErrors) != 1 {
+			t.Fatalf("Expected 1 row error, got %v", cl.Report.RowErrors)
+		}
+		var rowErr RowParseError
+		if !errors.As(error(cl.Report.RowErrors[0]), &rowErr) {
+			t.Fatalf("Expected a RowParseError")
+		}
+		if rowErr.Line != 3 || rowErr.Section != "objection" || !strings.HasPrefix(rowErr.Raw, "2 | 712980") {
+			t.Fatalf("Unexpected row error: %+v", rowErr)
+		}
+	})
+}
@@ -0,0 +1,331 @@
+package wclist
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+// LabelledItem is the expected value of each field of an item, keyed by the
+// names in ItemFields. Labels are written in the same form as items exported
+// as JSON, so a parse can be exported and corrected by hand. Fields that
+// aren't given are expected to be empty.
+type LabelledItem map[string]string
+
+// LabelItem returns the value of each field of an item
+func LabelItem(item CauseListItem) LabelledItem {
+	label := make(LabelledItem, len(ItemFields))
+	for _, field := range ItemFields {
+		label[field.Name] = field.Value(item)
+	}
+	return label
+}
+
+// ReadLabels reads the expected items of a document from a JSON array of
+// labelled items
+func ReadLabels(r io.Reader) ([]LabelledItem, error) {
+	var labels []LabelledItem
+	if err := json.NewDecoder(r).Decode(&labels); err != nil {
+		return nil, fmt.Errorf("reading labels: %w", err)
+	}
+	for i, label := range labels {
+		for name := range label {
+			if _, ok := LookupItemField(name); !ok {
+				return nil, fmt.Errorf("label %d: unknown field %q", i, name)
+			}
+		}
+	}
+	return labels, nil
+}
+
+// FieldScore counts how often the parser got one field right. A value is a
+// true positive when it matches the label, a false positive when the parser
+// gave a value that doesn't, and a false negative when a labelled value
+// wasn't found. Values are compared with runs of spaces collapsed.
+type FieldScore struct {
+	Field          string `json:"field"`
+	TruePositives  int    `json:"true_positives"`
+	FalsePositives int    `json:"false_positives"`
+	FalseNegatives int    `json:"false_negatives"`
+}
+
+// Precision is the fraction of the values given by the parser that are correct
+func (s FieldScore) Precision() float64 {
+	return ratio(s.TruePositives, s.TruePositives+s.FalsePositives)
+}
+
+// Recall is the fraction of the labelled values that the parser found
+func (s FieldScore) Recall() float64 {
+	return ratio(s.TruePositives, s.TruePositives+s.FalseNegatives)
+}
+
+// ratio returns n/d, or 1 when there is nothing to count
+func ratio(n, d int) float64 {
+	if d == 0 {
+		return 1
+	}
+	return float64(n) / float64(d)
+}
+
+// EvaluatedRow is a row that the parser missed or made up
+type EvaluatedRow struct {
+	Document string       `json:"document"`
+	Item     LabelledItem `json:"item"`
+}
+
+// FieldMismatch is a field of a matched row that the parser got wrong
+type FieldMismatch struct {
+	Document string `json:"document"`
+	Matter   string `json:"matter"`
+	Field    string `json:"field"`
+	Expected string `json:"expected"`
+	Actual   string `json:"actual"`
+}
+
+// Evaluation compares the items parsed from one or more documents with
+// their labels. Rows are matched by matter number; when a matter has more
+// than one row, each label is matched with the row that agrees with it on
+// the most fields. Labels left unmatched are missed rows and parsed items
+// left unmatched are spurious.
+type Evaluation struct {
+	Expected   int             `json:"expected"` // Labelled rows
+	Found      int             `json:"found"`    // Parsed rows
+	Matched    int             `json:"matched"`
+	Fields     []FieldScore    `json:"fields"` // In the order of ItemFields, without derived fields
+	Missed     []EvaluatedRow  `json:"missed"`
+	Spurious   []EvaluatedRow  `json:"spurious"`
+	Mismatches []FieldMismatch `json:"mismatches"`
+}
+
+// derivedFields are worked out from other fields, so they aren't scored on
+// their own
+var derivedFields = []string{"field"}
+
+// Evaluate compares the items parsed from a document with its labels. The
+// document name is recorded against missed and spurious rows and mismatches.
+func Evaluate(document string, labels []LabelledItem, items []CauseListItem) *Evaluation {
+	e := &Evaluation{Expected: len(labels), Found: len(items)}
+	for _, field := range ItemFields {
+		if !slices.Contains(derivedFields, field.Name) {
+			e.Fields = append(e.Fields, FieldScore{Field: field.Name})
+		}
+	}
+
+	parsed := labelItems(items)
+	pairs := pairRows(labels, parsed)
+	paired := make([]bool, len(parsed))
+
+	for j, label := range labels {
+		best := pairs[j]
+		if best < 0 {
+			e.Missed = append(e.Missed, EvaluatedRow{Document: document, Item: label})
+			e.score(label, nil)
+			continue
+		}
+
+		paired[best] = true
+		e.Matched++
+		for _, mismatch := range e.score(label, parsed[best]) {
+			mismatch.Document = document
+			e.Mismatches = append(e.Mismatches, mismatch)
+		}
+	}
+
+	for i, actual := range parsed {
+		if !paired[i] {
+			e.Spurious = append(e.Spurious, EvaluatedRow{Document: document, Item: actual})
+			e.score(nil, actual)
+		}
+	}
+	return e
+}
+
+// labelItems returns the value of each field of the items
+func labelItems(items []CauseListItem) []LabelledItem {
+	labels := make([]LabelledItem, len(items))
+	for i, item := range items {
+		labels[i] = LabelItem(item)
+	}
+	return labels
+}
+
+// pairRows pairs each expected row with a row of the same matter number,
+// choosing the one that agrees with it on the most fields. It returns the
+// index of each expected row's pair among the actual rows, or -1.
+func pairRows(expected, actual []LabelledItem) []int {
+	pairs := make([]int, len(expected))
+	paired := make([]bool, len(actual))
+	for j, want := range expected {
+		best, bestAgreement := -1, -1
+		for i, got := range actual {
+			if paired[i] || normalizeLabel(got["matter"]) != normalizeLabel(want["matter"]) {
+				continue
+			}
+			if agreement := agreeingFields(want, got); agreement > bestAgreement {
+				best, bestAgreement = i, agreement
+			}
+		}
+		if best >= 0 {
+			paired[best] = true
+		}
+		pairs[j] = best
+	}
+	return pairs
+}
+
+// score counts each field of a row, where either side is nil for missed and
+// spurious rows, and returns the fields of a matched row that differ
+func (e *Evaluation) score(expected, actual LabelledItem) []FieldMismatch {
+	var mismatches []FieldMismatch
+	for i := range e.Fields {
+		s := &e.Fields[i]
+		want, got := normalizeLabel(expected[s.Field]), normalizeLabel(actual[s.Field])
+		if want != "" && got == want {
+			s.TruePositives++
+			continue
+		}
+		if got != "" {
+			s.FalsePositives++
+		}
+		if want != "" {
+			s.FalseNegatives++
+		}
+		if expected != nil && actual != nil && got != want {
+			mismatches = append(mismatches, FieldMismatch{Matter: normalizeLabel(expected["matter"]), Field: s.Field, Expected: want, Actual: got})
+		}
+	}
+	return mismatches
+}
+
+// agreeingFields counts the fields on which two rows agree
+func agreeingFields(a, b LabelledItem) int {
+	n := 0
+	for _, field := range ItemFields {
+		if !slices.Contains(derivedFields, field.Name) && normalizeLabel(a[field.Name]) == normalizeLabel(b[field.Name]) {
+			n++
+		}
+	}
+	return n
+}
+
+// normalizeLabel collapses runs of spaces so that line breaks in the source
+// don't count against a value
+func normalizeLabel(value string) string {
+	return strings.Join(strings.Fields(value), " ")
+}
+
+// Add combines the evaluation of another document with this one
+func (e *Evaluation) Add(other *Evaluation) {
+	if len(e.Fields) == 0 {
+		e.Fields = make([]FieldScore, len(other.Fields))
+		copy(e.Fields, other.Fields)
+	} else {
+		for i, s := range other.Fields {
+			e.Fields[i].TruePositives += s.TruePositives
+			e.Fields[i].FalsePositives += s.FalsePositives
+			e.Fields[i].FalseNegatives += s.FalseNegatives
+		}
+	}
+	e.Expected += other.Expected
+	e.Found += other.Found
+	e.Matched += other.Matched
+	e.Missed = append(e.Missed, other.Missed...)
+	e.Spurious = append(e.Spurious, other.Spurious...)
+	e.Mismatches = append(e.Mismatches, other.Mismatches...)
+}
+
+// Precision is the fraction of parsed rows that match a label
+func (e *Evaluation) Precision() float64 { return ratio(e.Matched, e.Found) }
+
+// Recall is the fraction of labelled rows that were parsed
+func (e *Evaluation) Recall() float64 { return ratio(e.Matched, e.Expected) }
+
+// Field returns the score of the field with the given name
+func (e *Evaluation) Field(name string) FieldScore {
+	for _, s := range e.Fields {
+		if s.Field == name {
+			return s
+		}
+	}
+	return FieldScore{Field: name}
+}
+
+// WriteReport writes the row and field scores followed by the missed and
+// spurious rows and the fields that were wrong
+func (e *Evaluation) WriteReport(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 8, 2, ' ', 0)
+	fmt.Fprintf(tw, "Rows: %d expected, %d found, %d matched\n", e.Expected, e.Found, e.Matched)
+	fmt.Fprintf(tw, "Row precision %.3f, recall %.3f\n\n", e.Precision(), e.Recall())
+
+	fmt.Fprintln(tw, "FIELD\tPRECISION\tRECALL\tCORRECT\tWRONG OR EXTRA\tMISSING")
+	for _, s := range e.Fields {
+		fmt.Fprintf(tw, "%s\t%.3f\t%.3f\t%d\t%d\t%d\n", s.Field, s.Precision(), s.Recall(), s.TruePositives, s.FalsePositives, s.FalseNegatives)
+	}
+
+	writeRows := func(title string, rows []EvaluatedRow) {
+		if len(rows) == 0 {
+			return
+		}
+		fmt.Fprintf(tw, "\n%s rows: %d\n", title, len(rows))
+		for _, row := range rows {
+			fmt.Fprintf(tw, "  %s\tmatter %s\t%s\t%s\t%s\n", row.Document, row.Item["matter"], row.Item["type"], row.Item["tenement"], rowParties(row.Item))
+		}
+	}
+	writeRows("Missed", e.Missed)
+	writeRows("Spurious", e.Spurious)
+
+	if len(e.Mismatches) > 0 {
+		fmt.Fprintf(tw, "\nWrong fields: %d\n", len(e.Mismatches))
+		for _, m := range e.Mismatches {
+			fmt.Fprintf(tw, "  %s\tmatter %s\t%s\texpected %q\tgot %q\n", m.Document, m.Matter, m.Field, m.Expected, m.Actual)
+		}
+	}
+	return tw.Flush()
+}
+
+// rowParties names the parties of a row for the report
+func rowParties(item LabelledItem) string {
+	var parties []string
+	for _, name := range []string{"objector", "applicant", "respondent"} {
+		if value := normalizeLabel(item[name]); value != "" {
+			parties = append(parties, value)
+		}
+	}
+	return strings.Join(parties, " v ")
+}
+
+// EvaluateFile reads a cause list document with the given options and
+// evaluates its items against the labels in a JSON file
+func EvaluateFile(ctx context.Context, documentPath, labelsPath string, opts ReadOptions) (*Evaluation, error) {
+	labelsFile, err := os.Open(labelsPath)
+	if err != nil {
+		return nil, err
+	}
+	defer labelsFile.Close()
+	labels, err := ReadLabels(labelsFile)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", labelsPath, err)
+	}
+
+	file, err := os.Open(documentPath)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	stat, err := file.Stat()
+	if err != nil {
+		return nil, err
+	}
+
+	cl := NewCauseList("", "", time.Time{})
+	if err := cl.ReadCauseListOptions(ctx, file, stat.Size(), opts); err != nil {
+		return nil, fmt.Errorf("%s: %w", documentPath, err)
+	}
+	return Evaluate(documentPath, labels, cl.Items), nil
+}
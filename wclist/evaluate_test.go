@@ -0,0 +1,146 @@
+package wclist
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestEvaluate(t *testing.T) {
+	labels := []LabelledItem{
+		{"type": "objection", "matter": "1", "objection": "698561", "tenement": "E 15/2082", "objector": "KARORA (HIGGINSVILLE) PTY LTD", "applicant": "FMG RESOURCES PTY LTD"},
+		{"type": "objection", "matter": "2", "objection": "712980", "tenement": "E 15/2098", "objector": "BEACON MINERALS LIMITED", "applicant": "WEST AUSTRALIAN PROSPECTORS PTY LTD"},
+		{"type": "forfeiture", "matter": "3", "tenement": "E 16/396", "applicant": "ASHCROFT, Sean Cameron", "respondent": "GOLD TIGER HOLDINGS (AUSTRALIA) PTY LTD"},
+	}
+	items := []CauseListItem{
+		// A spurious row for matter 1 comes first, so rows must be paired by agreement
+		ObjectionItems{
+			CLIItems:        CLIItems{MatterNumber: 1, TenementNumber: "E 15/2082"},
+			ObjectionNumber: 698562,
+			ObjectorName:    "FMG RESOURCES PTY LTD",
+		},
+		ObjectionItems{
+			CLIItems:        CLIItems{MatterNumber: 1, TenementNumber: "E 15/2082"},
+			ObjectionNumber: 698561,
+			ObjectorName:    "KARORA (HIGGINSVILLE)\nPTY LTD",
+			ApplicantName:   "FMG RESOURCES PTY LTD",
+		},
+		ObjectionItems{
+			CLIItems:        CLIItems{MatterNumber: 2, TenementNumber: "E 15/2098"},
+			ObjectionNumber: 712980,
+			ObjectorName:    "698563 BEACON MINERALS LIMITED",
+			ApplicantName:   "WEST AUSTRALIAN PROSPECTORS PTY LTD",
+		},
+	}
+	e := Evaluate("cause_list.pdf", labels, items)
+
+	t.Run("Rows", func(t *testing.T) {
+		if e.Expected != 3 || e.Found != 3 || e.Matched != 2 {
+			t.Fatalf("Expected 2 of 3 rows matched, got %d of %d from %d", e.Matched, e.Expected, e.Found)
+		}
+		if len(e.Missed) != 1 || e.Missed[0].Item["matter"] != "3" || e.Missed[0].Document != "cause_list.pdf" {
+			t.Fatalf("Expected matter 3 to be missed, got %v", e.Missed)
+		}
+		if len(e.Spurious) != 1 || e.Spurious[0].Item["objection"] != "698562" {
+			t.Fatalf("Expected the second objection for matter 1 to be spurious, got %v", e.Spurious)
+		}
+		if e.Precision() != 2.0/3 || e.Recall() != 2.0/3 {
+			t.Fatalf("Expected row precision and recall of 2/3, got %v and %v", e.Precision(), e.Recall())
+		}
+	})
+
+	t.Run("Fields", func(t *testing.T) {
+		// The objector of matter 2 is wrong, the spurious row's objector is
+		// extra and the missed forfeiture has no objector
+		objector := e.Field("objector")
+		if objector.TruePositives != 1 || objector.FalsePositives != 2 || objector.FalseNegatives != 1 {
+			t.Fatalf("Unexpected objector score %+v", objector)
+		}
+		if objector.Precision() != 1.0/3 || objector.Recall() != 0.5 {
+			t.Fatalf("Expected objector precision 1/3 and recall 1/2, got %v and %v", objector.Precision(), objector.Recall())
+		}
+		respondent := e.Field("respondent")
+		if respondent.Precision() != 1 || respondent.Recall() != 0 {
+			t.Fatalf("Expected the missed respondent to lower only recall, got %+v", respondent)
+		}
+		if len(e.Mismatches) != 1 || e.Mismatches[0] != (FieldMismatch{
+			Document: "cause_list.pdf", Matter: "2", Field: "objector",
+			Expected: "BEACON MINERALS LIMITED", Actual: "698563 BEACON MINERALS LIMITED",
+		}) {
+			t.Fatalf("Expected only the objector of matter 2 to be wrong, got %+v", e.Mismatches)
+		}
+	})
+
+	t.Run("Combined", func(t *testing.T) {
+		total := &Evaluation{}
+		total.Add(e)
+		total.Add(Evaluate("other.pdf", labels[2:], nil))
+		if total.Expected != 4 || total.Matched != 2 || len(total.Missed) != 2 || total.Field("respondent").FalseNegatives != 2 {
+			t.Fatalf("Expected the evaluations to be summed, got %+v", total)
+		}
+		if e.Field("respondent").FalseNegatives != 1 {
+			t.Fatal("Expected adding to leave the first evaluation unchanged")
+		}
+	})
+
+	t.Run("Report", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := e.WriteReport(&buf); err != nil {
+			t.Fatalf("Failed to write report: %v", err)
+		}
+		report := buf.String()
+		for _, want := range []string{
+			"Rows: 3 expected, 3 found, 2 matched",
+			"Missed rows: 1",
+			"matter 3  forfeiture",
+			`objector  expected "BEACON MINERALS LIMITED"  got "698563 BEACON MINERALS LIMITED"`,
+		} {
+			if !strings.Contains(report, want) {
+				t.Fatalf("Expected the report to contain %q, got:\n%s", want, report)
+			}
+		}
+	})
+
+	t.Run("Unknown field", func(t *testing.T) {
+		_, err := ReadLabels(strings.NewReader(`[{"matter": "1", "objecter": "KARORA"}]`))
+		if err == nil || !strings.Contains(err.Error(), `unknown field "objecter"`) {
+			t.Fatalf("Expected an unknown field error, got %v", err)
+		}
+	})
+}
+
+// TestEvaluateCauseList scores the parser against the hand-labelled items of
+// testdata/cause_list.pdf. The floors are the scores of the parser when the
+// labels were written; raise them as it improves.
+func TestEvaluateCauseList(t *testing.T) {
+	opts := DefaultReadOptions()
+	opts.OCR = nil
+	e, err := EvaluateFile(context.Background(), "testdata/cause_list.pdf", "testdata/cause_list.json", opts)
+	if err != nil {
+		t.Fatalf("Failed to evaluate: %v", err)
+	}
+	if e.Expected != 94 {
+		t.Fatalf("Expected 94 labelled rows, got %d", e.Expected)
+	}
+
+	floors := []struct {
+		field             string
+		precision, recall float64
+	}{
+		{"matter", 0.70, 0.88},
+		{"objection", 0.70, 1},
+		{"tenement", 0.50, 0.62},
+		{"objector", 0.56, 0.79},
+		{"applicant", 0.50, 0.66},
+	}
+	for _, floor := range floors {
+		s := e.Field(floor.field)
+		if s.Precision() < floor.precision || s.Recall() < floor.recall {
+			var buf bytes.Buffer
+			e.WriteReport(&buf)
+			t.Fatalf("Expected %s precision of at least %v and recall of at least %v, got %.3f and %.3f\n%s",
+				floor.field, floor.precision, floor.recall, s.Precision(), s.Recall(), buf.String())
+		}
+	}
+}
@@ -0,0 +1,179 @@
+package wclist
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// ExportFormat is a file format that items and matches can be exported to
+type ExportFormat string
+
+const (
+	FormatCSV  ExportFormat = "csv"
+	FormatJSON ExportFormat = "json"
+	FormatXLSX ExportFormat = "xlsx"
+)
+
+// ParseExportFormat returns the export format with the given name
+func ParseExportFormat(name string) (ExportFormat, error) {
+	switch format := ExportFormat(strings.ToLower(name)); format {
+	case FormatCSV, FormatJSON, FormatXLSX:
+		return format, nil
+	}
+	return "", fmt.Errorf("unsupported export format %q", name)
+}
+
+// ContentType returns the MIME type of the format
+func (f ExportFormat) ContentType() string {
+	switch f {
+	case FormatCSV:
+		return "text/csv"
+	case FormatJSON:
+		return "application/json"
+	case FormatXLSX:
+		return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	}
+	return "application/octet-stream"
+}
+
+// exportColumn is a column of an export, with a JSON key and a heading
+type exportColumn struct {
+	Name   string
+	Header string
+}
+
+// exportTable is the tabular form shared by every export format
+type exportTable struct {
+	Sheet   string
+	Columns []exportColumn
+	Rows    [][]string
+}
+
+// WriteItems writes cause list items in the given format, one row per item
+// and one column per item field
+func WriteItems(w io.Writer, format ExportFormat, items []CauseListItem) error {
+	return writeTable(w, format, itemTable(items))
+}
+
+// WriteMatches writes match results in the given format, one row per match.
+// The assigned matter and match reason come first, followed by the fields
+// of the matched item.
+func WriteMatches(w io.Writer, format ExportFormat, matches []MatchResult) error {
+	return writeTable(w, format, matchTable(matches))
+}
+
+// Export writes the items of the cause list in the given format
+func (cl *CauseList) Export(w io.Writer, format ExportFormat) error {
+	return WriteItems(w, format, cl.Items)
+}
+
+func itemColumns() []exportColumn {
+	columns := make([]exportColumn, len(ItemFields))
+	for i, field := range ItemFields {
+		columns[i] = exportColumn{Name: field.Name, Header: field.Header}
+	}
+	return columns
+}
+
+func itemRow(item CauseListItem) []string {
+	row := make([]string, len(ItemFields))
+	for i, field := range ItemFields {
+		row[i] = field.Value(item)
+	}
+	return row
+}
+
+func itemTable(items []CauseListItem) exportTable {
+	table := exportTable{Sheet: "Items", Columns: itemColumns()}
+	for _, item := range items {
+		table.Rows = append(table.Rows, itemRow(item))
+	}
+	return table
+}
+
+func matchTable(matches []MatchResult) exportTable {
+	table := exportTable{
+		Sheet: "Matches",
+		Columns: append([]exportColumn{
+			{Name: "client", Header: "Client"},
+			{Name: "assigned_tenement", Header: "Assigned Tenement"},
+			{Name: "other_parties", Header: "Other Parties"},
+			{Name: "match_reason", Header: "Match Reason"},
+		}, itemColumns()...),
+	}
+
+	for _, match := range matches {
+		row := []string{
+			match.AssignedMatter.ClientName,
+			match.AssignedMatter.TenementNumber,
+			strings.Join(match.AssignedMatter.OtherPartyNames, "; "),
+			match.MatchReason,
+		}
+		table.Rows = append(table.Rows, append(row, itemRow(match.CauseListItem)...))
+	}
+	return table
+}
+
+func writeTable(w io.Writer, format ExportFormat, table exportTable) error {
+	switch format {
+	case FormatCSV:
+		return writeCSV(w, table)
+	case FormatJSON:
+		return writeJSON(w, table)
+	case FormatXLSX:
+		return writeXLSX(w, table)
+	}
+	return fmt.Errorf("unsupported export format %q", format)
+}
+
+// writeCSV writes a header row followed by the table rows
+func writeCSV(w io.Writer, table exportTable) error {
+	cw := csv.NewWriter(w)
+
+	headers := make([]string, len(table.Columns))
+	for i, column := range table.Columns {
+		headers[i] = column.Header
+	}
+	if err := cw.Write(headers); err != nil {
+		return err
+	}
+	if err := cw.WriteAll(table.Rows); err != nil {
+		return err
+	}
+	return cw.Error()
+}
+
+// writeJSON writes an array with one object per row. Keys are the column
+// names, written in column order.
+func writeJSON(w io.Writer, table exportTable) error {
+	bw := bufio.NewWriter(w)
+
+	bw.WriteString("[")
+	for r, row := range table.Rows {
+		if r > 0 {
+			bw.WriteString(",")
+		}
+		bw.WriteString("\n  {")
+		for c, column := range table.Columns {
+			if c > 0 {
+				bw.WriteString(", ")
+			}
+			key, _ := json.Marshal(column.Name)
+			value, _ := json.Marshal(row[c])
+			bw.Write(key)
+			bw.WriteString(": ")
+			bw.Write(value)
+		}
+		bw.WriteString("}")
+	}
+	if len(table.Rows) > 0 {
+		bw.WriteString("\n")
+	}
+	bw.WriteString("]\n")
+
+	return bw.Flush()
+}
@@ -0,0 +1,86 @@
+package wclist
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestExport(t *testing.T) {
+	items := []CauseListItem{
+		ObjectionItems{
+			CLIItems:        CLIItems{MatterNumber: 1, TenementNumber: "E 15/2082"},
+			ObjectionNumber: 698561,
+			ObjectorName:    "KARORA (HIGGINSVILLE) PTY LTD",
+			ApplicantName:   "FMG RESOURCES PTY LTD",
+		},
+		ForfeitureItems{
+			CLIItems:       CLIItems{MatterNumber: 86, TenementNumber: "M 24/37", Comments: "In Chambers"},
+			ApplicantName:  "VAN BLITTERSWYK, Wayne Craig",
+			RespondentName: "GARDNER, Robert Charles",
+		},
+	}
+
+	t.Run("CSV", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := WriteItems(&buf, FormatCSV, items); err != nil {
+			t.Fatalf("Failed to write CSV: %v", err)
+		}
+		records, err := csv.NewReader(&buf).ReadAll()
+		if err != nil {
+			t.Fatalf("Failed to read CSV: %v", err)
+		}
+		if len(records) != 3 || records[0][2] != "Objection Number" || records[1][2] != "698561" {
+			t.Fatalf("Unexpected CSV records: %v", records)
+		}
+		if records[2][7] != "GARDNER, Robert Charles" {
+			t.Fatalf("Expected the respondent column to be filled, got %v", records[2])
+		}
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		matches := []MatchResult{{
+			AssignedMatter: AssignedMatter{ClientName: "Gardner", OtherPartyNames: []string{"A", "B"}},
+			CauseListItem:  items[1],
+			MatchReason:    reasonClientResponding,
+		}}
+		var buf bytes.Buffer
+		if err := WriteMatches(&buf, FormatJSON, matches); err != nil {
+			t.Fatalf("Failed to write JSON: %v", err)
+		}
+		var rows []map[string]string
+		if err := json.Unmarshal(buf.Bytes(), &rows); err != nil {
+			t.Fatalf("Failed to read JSON: %v", err)
+		}
+		if len(rows) != 1 || rows[0]["other_parties"] != "A; B" || rows[0]["type"] != "forfeiture" {
+			t.Fatalf("Unexpected JSON rows: %v", rows)
+		}
+	})
+
+	t.Run("XLSX", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := WriteItems(&buf, FormatXLSX, items); err != nil {
+			t.Fatalf("Failed to write XLSX: %v", err)
+		}
+		zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+		if err != nil {
+			t.Fatalf("Failed to open XLSX: %v", err)
+		}
+		var sheet string
+		for _, f := range zr.File {
+			if f.Name == "xl/worksheets/sheet1.xml" {
+				rc, _ := f.Open()
+				data, _ := io.ReadAll(rc)
+				rc.Close()
+				sheet = string(data)
+			}
+		}
+		if !strings.Contains(sheet, `<c r="C2"><v>698561</v></c>`) || !strings.Contains(sheet, "KARORA (HIGGINSVILLE) PTY LTD") {
+			t.Fatalf("Worksheet is missing expected cells: %s", sheet)
+		}
+	})
+}
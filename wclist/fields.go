@@ -0,0 +1,128 @@
+package wclist
+
+import (
+	"regexp"
+	"strconv"
+	"time"
+)
+
+// ItemField describes a value that can be read from any cause list item.
+// Fields are used for queries and as columns when exporting items.
+type ItemField struct {
+	Name   string // Key used in queries, e.g. "objector"
+	Header string // Column heading, e.g. "Objector"
+	Value  func(item CauseListItem) string
+}
+
+// ItemFields lists the fields available on cause list items, in column order.
+// Type-specific fields are empty for items of other types.
+var ItemFields = []ItemField{
+	{Name: "type", Header: "Type", Value: ItemType},
+	{Name: "matter", Header: "Matter Number", Value: func(item CauseListItem) string {
+		return strconv.FormatUint(item.GetMatterNumber(), 10)
+	}},
+	{Name: "objection", Header: "Objection Number", Value: func(item CauseListItem) string {
+		if objItem, ok := item.(ObjectionItems); ok {
+			return strconv.FormatUint(objItem.GetObjectionNumber(), 10)
+		}
+		return ""
+	}},
+	{Name: "tenement", Header: "Tenement", Value: func(item CauseListItem) string {
+		return item.GetTenementNumber()
+	}},
+	{Name: "field", Header: "Mineral Field", Value: func(item CauseListItem) string {
+		return MineralField(item.GetTenementNumber())
+	}},
+	{Name: "objector", Header: "Objector", Value: func(item CauseListItem) string {
+		if objItem, ok := item.(ObjectionItems); ok {
+			return objItem.ObjectorName
+		}
+		return ""
+	}},
+	{Name: "applicant", Header: "Applicant", Value: func(item CauseListItem) string {
+		return item.GetApplyingParty()
+	}},
+	{Name: "respondent", Header: "Respondent", Value: func(item CauseListItem) string {
+		switch v := item.(type) {
+		case ForfeitureItems:
+			return v.RespondentName
+		case ExemptionItems:
+			return v.RespondentName
+		case PlaintItems:
+			return v.DefendantName
+		}
+		return ""
+	}},
+	{Name: "comments", Header: "Comments", Value: func(item CauseListItem) string {
+		return item.GetComments()
+	}},
+	{Name: "date", Header: "Application Date", Value: func(item CauseListItem) string {
+		if appItem, ok := item.(ApplicationItems); ok && !appItem.ApplicationDate.IsZero() {
+			return appItem.ApplicationDate.Format("2006-01-02")
+		}
+		return ""
+	}},
+	{Name: "relief", Header: "Relief Sought", Value: func(item CauseListItem) string {
+		switch v := item.(type) {
+		case PlaintItems:
+			return v.ReliefSought
+		case RestorationItems:
+			return v.ReliefSought
+		}
+		return ""
+	}},
+	{Name: "status", Header: "Status", Value: func(item CauseListItem) string {
+		if v, ok := item.(interface{ GetStatus() MatterStatus }); ok {
+			return string(v.GetStatus())
+		}
+		return ""
+	}},
+	{Name: "adjourned", Header: "Adjourned To", Value: func(item CauseListItem) string {
+		if v, ok := item.(interface{ GetAdjournedTo() time.Time }); ok && !v.GetAdjournedTo().IsZero() {
+			return v.GetAdjournedTo().Format("2006-01-02")
+		}
+		return ""
+	}},
+}
+
+// LookupItemField returns the field with the given query name
+func LookupItemField(name string) (ItemField, bool) {
+	for _, field := range ItemFields {
+		if field.Name == name {
+			return field, true
+		}
+	}
+	return ItemField{}, false
+}
+
+// ItemType returns the section type of a cause list item. Items of
+// registered matter types report their own type.
+func ItemType(item CauseListItem) string {
+	switch item := item.(type) {
+	case ObjectionItems:
+		return "objection"
+	case ForfeitureItems:
+		return "forfeiture"
+	case ExemptionItems:
+		return "exemption"
+	case ApplicationItems:
+		return "application"
+	case PlaintItems:
+		return "plaint"
+	case RestorationItems:
+		return "restoration"
+	case interface{ ItemType() string }:
+		return item.ItemType()
+	}
+	return "unknown"
+}
+
+var mineralFieldPattern = regexp.MustCompile(`(\d+)\s*/`)
+
+// MineralField returns the mineral field number of a tenement, e.g. "15" for "E 15/2082"
+func MineralField(tenement string) string {
+	if matches := mineralFieldPattern.FindStringSubmatch(tenement); len(matches) == 2 {
+		return matches[1]
+	}
+	return ""
+}
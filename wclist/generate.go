@@ -0,0 +1,459 @@
+package wclist
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// GenerateOptions describes a synthetic cause list written by
+// GenerateCauseList. The same options always give the same document.
+type GenerateOptions struct {
+	Seed int64 // Seeds the choice of parties, tenements and comments
+
+	// Sections lists the matter types in the order they're heard.
+	// Objection, forfeiture and exemption sections can be generated; all
+	// three are by default.
+	Sections []string
+	Matters  int // Matters in each section, 20 by default
+
+	// RowsPerPage is the most rows on a page. Pages otherwise fill to the
+	// bottom margin, so long sections break across pages either way.
+	RowsPerPage int
+	// MaxTenements is the most tenements heard together in one row, 3 by
+	// default. Objections list an objection number for each.
+	MaxTenements int
+
+	Place  string    // Where the court sits, Kalgoorlie by default
+	Warden string    // Chosen from the seed by default
+	Date   time.Time // Sitting date, 24 June 2025 by default
+}
+
+// withDefaults fills in the options that aren't set
+func (o GenerateOptions) withDefaults() GenerateOptions {
+	if len(o.Sections) == 0 {
+		o.Sections = []string{"objection", "forfeiture", "exemption"}
+	}
+	if o.Matters <= 0 {
+		o.Matters = 20
+	}
+	if o.MaxTenements <= 0 {
+		o.MaxTenements = 3
+	}
+	if o.Place == "" {
+		o.Place = "Kalgoorlie"
+	}
+	if o.Date.IsZero() {
+		o.Date = time.Date(2025, time.June, 24, 10, 0, 0, 0, time.UTC)
+	}
+	return o
+}
+
+// generatedColumn is a column of a generated section's table
+type generatedColumn struct {
+	heading string  // Written on one line, so that header keywords match it
+	x       float64 // Left edge on the page
+	width   int     // Characters before a cell wraps
+}
+
+// generatedSections lays out the sections GenerateCauseList can write, in
+// the column order of their parsers
+var generatedSections = map[string]struct {
+	title   string
+	columns []generatedColumn
+}{
+	"objection": {"OBJECTIONS", []generatedColumn{
+		{"MATTER NUMBER", 36, 6}, {"OBJECTION NUMBER", 90, 8}, {"OBJECTOR", 150, 30},
+		{"TENEMENT AFFECTED", 360, 14}, {"APPLICANT", 440, 30}, {"COMMENTS", 650, 28},
+	}},
+	"forfeiture": {"APPLICATIONS FOR FORFEITURE", []generatedColumn{
+		{"MATTER NUMBER", 36, 6}, {"TENEMENT AFFECTED", 90, 14}, {"APPLICANT FOR FORFEITURE", 170, 32},
+		{"RESPONDENT", 400, 32}, {"COMMENTS", 630, 30},
+	}},
+	"exemption": {"APPLICATIONS FOR EXEMPTION", []generatedColumn{
+		{"MATTER NUMBER", 36, 6}, {"TENEMENT AFFECTED", 90, 14}, {"APPLICANT FOR EXEMPTION", 170, 32},
+		{"RESPONDENT", 400, 32}, {"COMMENTS", 630, 30},
+	}},
+}
+
+// Page geometry of generated cause lists, in points, on landscape A4
+const (
+	generatedPageWidth  = 842
+	generatedPageHeight = 595
+	generatedMargin     = 36
+	generatedFontSize   = 8
+	generatedLeading    = 10
+	generatedRowGap     = 6
+)
+
+// GenerateCauseList writes a synthetic cause list PDF in the layout of the
+// Warden's Court lists, for fixtures and load tests that can't use real
+// client data. It has a cover page, and a section of each type in
+// opts.Sections whose rows have party names wrapped over several lines,
+// several tenements heard together and comments. Sections continue across
+// pages with their headers repeated. It returns the items the list holds,
+// labelled as in Evaluate: a row of tenements heard together is one item
+// with the tenements separated by commas and, for objections, the first
+// objection number.
+func GenerateCauseList(w io.Writer, opts GenerateOptions) ([]CauseListItem, error) {
+	opts = opts.withDefaults()
+	for _, section := range opts.Sections {
+		if _, ok := generatedSections[section]; !ok {
+			return nil, fmt.Errorf("can't generate %s sections", section)
+		}
+	}
+
+	g := &listGenerator{
+		rng:       rand.New(rand.NewPCG(uint64(opts.Seed), 0x5eed)),
+		opts:      opts,
+		objection: 600000,
+	}
+	if opts.Warden == "" {
+		g.opts.Warden = pick(g.rng, generatedWardens)
+	}
+	g.reference = fmt.Sprintf("TNT-%04d", g.rng.IntN(10000))
+
+	g.coverPage()
+	matter := uint64(0)
+	for _, section := range opts.Sections {
+		g.startSection(section, true)
+		for range opts.Matters {
+			matter++
+			g.addRow(section, matter)
+		}
+		g.finishPage()
+	}
+
+	var buf bytes.Buffer
+	writeGeneratedPDF(&buf, g.pages)
+	if _, err := w.Write(buf.Bytes()); err != nil {
+		return nil, err
+	}
+	return g.items, nil
+}
+
+// listGenerator builds the pages of a synthetic cause list and the items
+// they hold
+type listGenerator struct {
+	rng       *rand.Rand
+	opts      GenerateOptions
+	reference string // Document reference printed at the top of each page
+	objection uint64 // Last objection number given out
+
+	pages []string
+	page  strings.Builder // Content of the page being written
+	y     float64         // Baseline of the next line on the page
+	rows  int             // Rows on the page
+	items []CauseListItem
+}
+
+// line writes one line of text at a position on the page
+func (g *listGenerator) line(x, y float64, size float64, text string) {
+	fmt.Fprintf(&g.page, "BT /F1 %s Tf 1 0 0 1 %s %s Tm %s Tj ET\n", pdfNumber(size), pdfNumber(x), pdfNumber(y), pdfTextString(text))
+}
+
+// coverPage writes the page naming the court, warden and sitting
+func (g *listGenerator) coverPage() {
+	place := strings.ToUpper(g.opts.Place)
+	g.page.Reset()
+	g.line(generatedMargin, generatedPageHeight-generatedMargin, generatedFontSize, g.reference)
+	y := float64(generatedPageHeight - 150)
+	for _, text := range []string{
+		"RESOURCE TENURE DIVISION",
+		"WARDEN'S COURT " + place,
+		fmt.Sprintf("COURT HOUSE, %s, WA", place),
+		"BEFORE WARDEN " + strings.ToUpper(g.opts.Warden),
+		"AT " + strings.ToUpper(g.opts.Date.Format("3:04 PM")),
+		fmt.Sprintf("ON %d%s %s", g.opts.Date.Day(), ordinalSuffix(g.opts.Date.Day()), g.opts.Date.Format("January 2006")),
+	} {
+		g.line(250, y, 14, text)
+		y -= 24
+	}
+	g.pages = append(g.pages, g.page.String())
+}
+
+// ordinalSuffix returns the suffix of a day of the month, e.g. "th" for 24
+func ordinalSuffix(day int) string {
+	switch {
+	case day >= 11 && day <= 13:
+		return "th"
+	case day%10 == 1:
+		return "st"
+	case day%10 == 2:
+		return "nd"
+	case day%10 == 3:
+		return "rd"
+	}
+	return "th"
+}
+
+// startSection begins a page of a section, with the section's title when
+// it starts the section and the column headings
+func (g *listGenerator) startSection(section string, first bool) {
+	layout := generatedSections[section]
+	g.page.Reset()
+	g.rows = 0
+
+	g.y = generatedPageHeight - generatedMargin
+	g.line(generatedMargin, g.y, generatedFontSize, g.reference)
+	g.y -= 2 * generatedLeading
+	if first {
+		g.line(generatedMargin, g.y, 11, layout.title)
+		g.y -= 2 * generatedLeading
+	}
+	for _, column := range layout.columns {
+		g.line(column.x, g.y, generatedFontSize, column.heading)
+	}
+	g.y -= generatedLeading + generatedRowGap
+}
+
+// finishPage ends the page being written
+func (g *listGenerator) finishPage() {
+	g.pages = append(g.pages, g.page.String())
+	g.page.Reset()
+}
+
+// addRow writes a row of a section, starting a new page when it doesn't fit
+func (g *listGenerator) addRow(section string, matter uint64) {
+	item, cells := g.row(section, matter)
+	layout := generatedSections[section]
+
+	// Each cell wraps to the width of its column
+	lines := make([][]string, len(cells))
+	height := 1
+	for i, cell := range cells {
+		for _, part := range cell {
+			lines[i] = append(lines[i], wrapCell(part, layout.columns[i].width)...)
+		}
+		height = max(height, len(lines[i]))
+	}
+
+	full := g.opts.RowsPerPage > 0 && g.rows >= g.opts.RowsPerPage
+	if full || g.y-float64(height-1)*generatedLeading < generatedMargin {
+		g.finishPage()
+		g.startSection(section, false)
+	}
+
+	// The matter number is a text object of its own, followed by one for
+	// each line of the row holding the cells that reach that line
+	g.line(layout.columns[0].x, g.y, generatedFontSize, lines[0][0])
+	for j := range height {
+		fmt.Fprintf(&g.page, "BT /F1 %d Tf\n", generatedFontSize)
+		for i := 1; i < len(lines); i++ {
+			if j < len(lines[i]) {
+				fmt.Fprintf(&g.page, "1 0 0 1 %s %s Tm %s Tj\n", pdfNumber(layout.columns[i].x),
+					pdfNumber(g.y-float64(j)*generatedLeading), pdfTextString(lines[i][j]+" "))
+			}
+		}
+		g.page.WriteString("ET\n")
+	}
+	g.y -= float64(height)*generatedLeading + generatedRowGap
+	g.rows++
+	g.items = append(g.items, item)
+}
+
+// wrapCell breaks the text of a cell into lines of at most width characters
+func wrapCell(text string, width int) []string {
+	lines := wrapText(text, width)
+	for i := range lines {
+		lines[i] = strings.TrimSpace(lines[i]) // wrapText indents the lines it continues
+	}
+	return lines
+}
+
+// row chooses the contents of a row, returning its item and the parts of
+// each of its cells in column order. Each part starts a new line.
+func (g *listGenerator) row(section string, matter uint64) (CauseListItem, [][]string) {
+	tenements := g.tenements()
+	comments := g.comments()
+	var commentCell []string
+	if comments != "" {
+		commentCell = []string{comments}
+	}
+	matterCell := []string{strconv.FormatUint(matter, 10)}
+
+	switch section {
+	case "objection":
+		var first uint64
+		numbers := make([]string, len(tenements))
+		for i := range numbers {
+			g.objection += 1 + uint64(g.rng.IntN(40))
+			if i == 0 {
+				first = g.objection
+			}
+			numbers[i] = strconv.FormatUint(g.objection, 10)
+		}
+		objector, applicant := g.parties(g.company), g.parties(g.holder)
+		return ObjectionItems{
+			CLIItems:        newCLIItems(matter, strings.Join(tenements, ", "), comments),
+			ObjectionNumber: first,
+			ObjectorName:    objector,
+			ApplicantName:   applicant,
+		}, [][]string{matterCell, numbers, {objector}, tenements, {applicant}, commentCell}
+
+	case "forfeiture":
+		applicant, respondent := g.holder(), g.parties(g.holder)
+		return ForfeitureItems{
+			CLIItems:       newCLIItems(matter, strings.Join(tenements, ", "), comments),
+			ApplicantName:  applicant,
+			RespondentName: respondent,
+		}, [][]string{matterCell, tenements, {applicant}, {respondent}, commentCell}
+
+	default:
+		applicant, respondent := g.parties(g.holder), g.holder()
+		return ExemptionItems{
+			CLIItems:       newCLIItems(matter, strings.Join(tenements, ", "), comments),
+			ApplicantName:  applicant,
+			RespondentName: respondent,
+		}, [][]string{matterCell, tenements, {applicant}, {respondent}, commentCell}
+	}
+}
+
+// tenements chooses the tenements heard together in a row: tenements of
+// one type and mineral field, numbered one after another
+func (g *listGenerator) tenements() []string {
+	kind := pick(g.rng, []string{"E", "E", "P", "P", "P", "M", "L", "G"})
+	field := 15 + g.rng.IntN(66)
+	number := 1 + g.rng.IntN(generatedTenementNumbers[kind])
+	suffix := ""
+	if kind == "P" && g.rng.IntN(5) == 0 {
+		suffix = "-S" // Prospecting licences for special prospecting
+	}
+
+	n := 1
+	if g.opts.MaxTenements > 1 && g.rng.IntN(5) == 0 {
+		n = 2 + g.rng.IntN(g.opts.MaxTenements-1)
+	}
+	tenements := make([]string, n)
+	for i := range tenements {
+		tenements[i] = fmt.Sprintf("%s %d/%d%s", kind, field, number+i, suffix)
+	}
+	return tenements
+}
+
+// comments chooses the comments of a row, which are usually empty
+func (g *listGenerator) comments() string {
+	if g.rng.IntN(3) > 0 {
+		return ""
+	}
+	switch g.rng.IntN(5) {
+	case 0:
+		date := g.opts.Date.AddDate(0, 0, 7*(1+g.rng.IntN(12)))
+		return "Adjourned to " + date.Format("2/01/2006")
+	case 1:
+		return fmt.Sprintf("Listed for hearing %d days", 1+g.rng.IntN(4))
+	case 2:
+		return "By consent"
+	case 3:
+		return "Withdrawn"
+	}
+	return "In Chambers"
+}
+
+// parties names one or two parties, joined by a comma
+func (g *listGenerator) parties(party func() string) string {
+	if g.rng.IntN(6) == 0 {
+		return party() + ", " + party()
+	}
+	return party()
+}
+
+// holder names a tenement holder, a company or a person
+func (g *listGenerator) holder() string {
+	if g.rng.IntN(3) == 0 {
+		return g.person()
+	}
+	return g.company()
+}
+
+// company names a company, e.g. "QUARTZ RIDGE MINERALS PTY LTD"
+func (g *listGenerator) company() string {
+	name := pick(g.rng, generatedPlaces) + " " + pick(g.rng, generatedBusinesses)
+	if g.rng.IntN(3) == 0 {
+		name = pick(g.rng, generatedPlaces) + " " + name
+	}
+	if g.rng.IntN(8) == 0 {
+		name += " (" + pick(g.rng, generatedPlaces) + ")"
+	}
+	return name + " " + pick(g.rng, []string{"PTY LTD", "PTY LTD", "LIMITED", "PTY LIMITED", "NL"})
+}
+
+// person names a person as cause lists do, e.g. "MORGAN, Alice Jane"
+func (g *listGenerator) person() string {
+	name := pick(g.rng, generatedSurnames) + ", " + pick(g.rng, generatedGivenNames)
+	if g.rng.IntN(2) == 0 {
+		name += " " + pick(g.rng, generatedGivenNames)
+	}
+	return name
+}
+
+// pick chooses one of the values
+func pick(rng *rand.Rand, values []string) string {
+	return values[rng.IntN(len(values))]
+}
+
+// Words for the names in generated cause lists. None of them are header
+// keywords or start comments, which would change how rows are read.
+var (
+	generatedPlaces = []string{
+		"QUARTZ", "SPINIFEX", "RED HILL", "IRONBARK", "SALT LAKE", "GIBSON", "MULGA", "BOULDER RIDGE",
+		"DINGO CREEK", "WHITE CLIFFS", "COOLGARDIE", "BLUEBUSH", "YILGARN", "EAST KAL", "MOUNT ROSE",
+		"SANDALWOOD", "GREAT VICTORIA", "NULLARBOR", "GOLDEN MILE", "LAKE LEFROY",
+	}
+	generatedBusinesses = []string{
+		"MINERALS", "RESOURCES", "MINING", "EXPLORATION", "PROSPECTING", "HOLDINGS", "GOLD",
+		"NICKEL", "LITHIUM", "METALS", "INVESTMENTS", "NOMINEES", "TENEMENT HOLDINGS",
+	}
+	generatedSurnames = []string{
+		"MORGAN", "TAYLOR", "NGUYEN", "O'CONNOR", "VAN DER BERG", "KOWALSKI", "BRADSHAW", "PATEL",
+		"MCALLISTER", "FITZGERALD", "HENDERSON", "ROSSI", "ANDERSON", "WILLIAMS", "DE SOUSA",
+	}
+	generatedGivenNames = []string{
+		"Alice", "James", "Priya", "Thomas", "Mei", "Daniel", "Rebecca", "Liam", "Sofia",
+		"Graham", "Kate", "Anthony", "Wayne", "Eleanor", "Mohammed",
+	}
+	generatedWardens = []string{"Hartley", "McKenzie", "O'Brien", "Langford", "Whitaker"}
+
+	// generatedTenementNumbers is roughly how high tenements of each type are numbered
+	generatedTenementNumbers = map[string]int{"E": 3500, "P": 7000, "M": 1500, "L": 600, "G": 80}
+)
+
+// writeGeneratedPDF writes a PDF with one page for each content stream,
+// using Helvetica as /F1
+func writeGeneratedPDF(buf *bytes.Buffer, contents []string) {
+	// Objects 1 to 3 are the catalog, page tree and font, followed by each
+	// page and its content stream
+	pageRef := func(i int) pdfRef { return pdfRef{num: 4 + 2*i} }
+	kids := make([]string, len(contents))
+	for i := range contents {
+		kids[i] = pageRef(i).String()
+	}
+
+	objects := []string{
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d /MediaBox [0 0 %d %d] >>", strings.Join(kids, " "), len(contents), generatedPageWidth, generatedPageHeight),
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>",
+	}
+	for i, content := range contents {
+		objects = append(objects,
+			fmt.Sprintf("<< /Type /Page /Parent 2 0 R /Resources << /Font << /F1 3 0 R >> >> /Contents %s >>", pdfRef{num: pageRef(i).num + 1}),
+			fmt.Sprintf("<< /Length %d >>\nstream\n%s\nendstream", len(content), content))
+	}
+
+	buf.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+	offsets := make([]int, len(objects))
+	for i, object := range objects {
+		offsets[i] = buf.Len()
+		fmt.Fprintf(buf, "%d 0 obj\n%s\nendobj\n", i+1, object)
+	}
+	xref := buf.Len()
+	fmt.Fprintf(buf, "xref\n0 %d\n0000000000 65535 f \n", len(objects)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(buf, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(buf, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(objects)+1, xref)
+}
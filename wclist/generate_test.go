@@ -0,0 +1,117 @@
+package wclist
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+// readGenerated parses a generated cause list
+func readGenerated(tb testing.TB, data []byte) *CauseList {
+	tb.Helper()
+	opts := DefaultReadOptions()
+	opts.OCR = nil
+	cl := NewCauseList("", "", time.Time{})
+	if err := cl.ReadCauseListOptions(context.Background(), bytes.NewReader(data), int64(len(data)), opts); err != nil {
+		tb.Fatalf("Failed to read generated cause list: %v", err)
+	}
+	return cl
+}
+
+func TestGenerateCauseList(t *testing.T) {
+	opts := GenerateOptions{Seed: 42, RowsPerPage: 8}
+	var buf bytes.Buffer
+	items, err := GenerateCauseList(&buf, opts)
+	if err != nil {
+		t.Fatalf("Failed to generate cause list: %v", err)
+	}
+
+	t.Run("Deterministic", func(t *testing.T) {
+		var again bytes.Buffer
+		againItems, err := GenerateCauseList(&again, opts)
+		if err != nil {
+			t.Fatalf("Failed to generate cause list: %v", err)
+		}
+		if !bytes.Equal(buf.Bytes(), again.Bytes()) || len(againItems) != len(items) {
+			t.Fatal("Expected the same seed to give the same document")
+		}
+
+		var other bytes.Buffer
+		if _, err := GenerateCauseList(&other, GenerateOptions{Seed: 43, RowsPerPage: 8}); err != nil {
+			t.Fatalf("Failed to generate cause list: %v", err)
+		}
+		if bytes.Equal(buf.Bytes(), other.Bytes()) {
+			t.Fatal("Expected another seed to give another document")
+		}
+	})
+
+	t.Run("Layout", func(t *testing.T) {
+		if len(items) != 60 {
+			t.Fatalf("Expected 20 matters in each of 3 sections, got %d", len(items))
+		}
+		for i, item := range items {
+			if item.GetMatterNumber() != uint64(i+1) {
+				t.Fatalf("Expected matters numbered from 1, got %d at %d", item.GetMatterNumber(), i)
+			}
+		}
+
+		var multiple, wrapped bool
+		for _, item := range items {
+			label := LabelItem(item)
+			multiple = multiple || strings.Contains(label["tenement"], ", ")
+			wrapped = wrapped || len(label["objector"]) > 30 || len(label["applicant"]) > 32
+		}
+		if !multiple || !wrapped {
+			t.Fatalf("Expected rows with several tenements (%v) and wrapped names (%v)", multiple, wrapped)
+		}
+
+		// A cover page and three pages for each section of 20 rows
+		cl := readGenerated(t, buf.Bytes())
+		if cl.Report.Pages != 10 || cl.Report.Profile != "wa-wardens-court" {
+			t.Fatalf("Expected 10 pages read as wa-wardens-court, got %d read as %q", cl.Report.Pages, cl.Report.Profile)
+		}
+	})
+
+	t.Run("Round trip", func(t *testing.T) {
+		var buf bytes.Buffer
+		items, err := GenerateCauseList(&buf, GenerateOptions{Seed: 7, Sections: []string{"objection"}, Matters: 40, RowsPerPage: 12})
+		if err != nil {
+			t.Fatalf("Failed to generate cause list: %v", err)
+		}
+		labels := make([]LabelledItem, len(items))
+		for i, item := range items {
+			labels[i] = LabelItem(item)
+		}
+
+		// Every row is found with its matter and objection numbers, however
+		// its names wrap
+		e := Evaluate("generated.pdf", labels, readGenerated(t, buf.Bytes()).Items)
+		for _, field := range []string{"matter", "objection"} {
+			if s := e.Field(field); s.Precision() != 1 || s.Recall() != 1 {
+				var report bytes.Buffer
+				e.WriteReport(&report)
+				t.Fatalf("Expected every %s to be read, got %+v\n%s", field, s, report.String())
+			}
+		}
+	})
+
+	t.Run("Unknown section", func(t *testing.T) {
+		_, err := GenerateCauseList(&bytes.Buffer{}, GenerateOptions{Sections: []string{"appeal"}})
+		if err == nil || !strings.Contains(err.Error(), "can't generate appeal sections") {
+			t.Fatalf("Expected an error for an appeal section, got %v", err)
+		}
+	})
+}
+
+func BenchmarkReadCauseList(b *testing.B) {
+	var buf bytes.Buffer
+	if _, err := GenerateCauseList(&buf, GenerateOptions{Seed: 1, Sections: []string{"objection"}, Matters: 2000}); err != nil {
+		b.Fatalf("Failed to generate cause list: %v", err)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		readGenerated(b, buf.Bytes())
+	}
+}
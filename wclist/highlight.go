@@ -0,0 +1,302 @@
+package wclist
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// highlightColor is the colour of highlights, as RGB components
+const highlightColor = "1 0.92 0.23"
+
+// Layout of the cover page, in points
+const (
+	coverMargin     = 48.0
+	coverTitleSize  = 16.0
+	coverFontSize   = 10.0
+	coverLineHeight = 14.0
+)
+
+// WriteHighlightedPDF writes a copy of the PDF a cause list was read from,
+// with a highlight annotation over the row of each matched item and a cover
+// page listing the matches. Matched items without a box on a page, such as
+// items read from other formats, are listed on the cover only.
+//
+// The copy is the original followed by an incremental update, so the
+// original pages are left exactly as they were. Encrypted PDFs give
+// ErrEncryptedPDF.
+func WriteHighlightedPDF(w io.Writer, original io.ReaderAt, size int64, matches []MatchResult) error {
+	return WriteHighlightedPDFContext(context.Background(), w, original, size, matches)
+}
+
+// WriteHighlightedPDFContext writes a highlighted copy of a PDF like
+// WriteHighlightedPDF, returning the context's error if it is cancelled
+// while the PDF's objects are read
+func WriteHighlightedPDFContext(ctx context.Context, w io.Writer, original io.ReaderAt, size int64, matches []MatchResult) error {
+	update, err := newPDFUpdate(ctx, original, size)
+	if err != nil {
+		return err
+	}
+	root, pages, err := update.pageRefs()
+	if err != nil {
+		return err
+	}
+
+	// Items matched by more than one assigned matter get one highlight
+	type highlight struct {
+		box      BBox
+		contents []string
+	}
+	highlights := make(map[int][]*highlight)
+	byRow := make(map[Provenance]*highlight)
+	for _, match := range matches {
+		source := ItemSource(match.CauseListItem)
+		if source.Page < 1 || source.Page > len(pages) || source.BBox.IsZero() {
+			continue
+		}
+		contents := match.AssignedMatter.ClientName + ": " + match.MatchReason
+		if h, ok := byRow[source]; ok {
+			h.contents = append(h.contents, contents)
+			continue
+		}
+		h := &highlight{box: source.BBox, contents: []string{contents}}
+		byRow[source] = h
+		highlights[source.Page] = append(highlights[source.Page], h)
+	}
+
+	var blend pdfRef
+	if len(byRow) > 0 {
+		blend = update.allocate()
+		update.writeObject(blend, "<< /Type /ExtGState /BM /Multiply >>")
+	}
+	for i, page := range pages {
+		if len(highlights[i+1]) == 0 {
+			continue
+		}
+		var annots []string
+		for _, h := range highlights[i+1] {
+			annots = append(annots, update.writeHighlight(page, blend, h.box, strings.Join(h.contents, "\n")).String())
+		}
+		if err := update.addAnnots(page, annots); err != nil {
+			return err
+		}
+	}
+
+	covers := update.writeCoverPages(root, matches)
+	if err := update.prependPages(root, covers); err != nil {
+		return err
+	}
+	return update.finish(w, original)
+}
+
+// writeHighlight writes a highlight annotation over box on a page, with an
+// appearance that multiplies the page by the highlight colour so the text
+// stays readable
+func (u *pdfUpdate) writeHighlight(page, blend pdfRef, box BBox, contents string) pdfRef {
+	box = BBox{X0: box.X0 - 1, Y0: box.Y0 - 1, X1: box.X1 + 1, Y1: box.Y1 + 1}
+	rect := fmt.Sprintf("[%s %s %s %s]", pdfNumber(box.X0), pdfNumber(box.Y0), pdfNumber(box.X1), pdfNumber(box.Y1))
+
+	appearance := u.allocate()
+	u.writeStream(appearance,
+		fmt.Sprintf("/Type /XObject /Subtype /Form /BBox %s /Resources << /ExtGState << /GS0 %s >> >>", rect, blend),
+		fmt.Appendf(nil, "/GS0 gs %s rg %s %s %s %s re f", highlightColor,
+			pdfNumber(box.X0), pdfNumber(box.Y0), pdfNumber(box.X1-box.X0), pdfNumber(box.Y1-box.Y0)))
+
+	annot := u.allocate()
+	u.writeObject(annot, fmt.Sprintf(
+		"<< /Type /Annot /Subtype /Highlight /Rect %s /QuadPoints [%s %s %s %s %s %s %s %s] /C [%s] /F 4 /P %s /T %s /Contents %s /AP << /N %s >> >>",
+		rect,
+		pdfNumber(box.X0), pdfNumber(box.Y1), pdfNumber(box.X1), pdfNumber(box.Y1),
+		pdfNumber(box.X0), pdfNumber(box.Y0), pdfNumber(box.X1), pdfNumber(box.Y0),
+		highlightColor, page, pdfHexString("wclist"), pdfHexString(contents), appearance))
+	return annot
+}
+
+// addAnnots rewrites a page with the annotations added to any it already has
+func (u *pdfUpdate) addAnnots(page pdfRef, annots []string) error {
+	entries, err := u.objectDict(page)
+	if err != nil {
+		return err
+	}
+	if value, ok := dictValue(entries, "Annots"); ok {
+		if value, err = u.objects.resolve(value); err != nil {
+			return err
+		}
+		if existing, err := parsePDFArray(value); err == nil {
+			annots = append(existing, annots...)
+		}
+	}
+	entries = setDictEntry(entries, "Annots", "["+strings.Join(annots, " ")+"]")
+	u.writeObject(page, formatDict(entries))
+	return nil
+}
+
+// prependPages rewrites the root of the page tree with the pages added
+// before its existing pages
+func (u *pdfUpdate) prependPages(root pdfRef, pages []pdfRef) error {
+	entries, err := u.objectDict(root)
+	if err != nil {
+		return err
+	}
+	value, _ := dictValue(entries, "Kids")
+	if value, err = u.objects.resolve(value); err != nil {
+		return err
+	}
+	kids, err := parsePDFArray(value)
+	if err != nil {
+		return err
+	}
+	count, err := u.objects.int(entries, "Count")
+	if err != nil {
+		return err
+	}
+
+	refs := make([]string, len(pages))
+	for i, page := range pages {
+		refs[i] = page.String()
+	}
+	entries = setDictEntry(entries, "Kids", "["+strings.Join(append(refs, kids...), " ")+"]")
+	entries = setDictEntry(entries, "Count", strconv.FormatInt(count+int64(len(pages)), 10))
+	u.writeObject(root, formatDict(entries))
+	return nil
+}
+
+// writeCoverPages writes pages listing the matches, the same size as the
+// first page of the document, and returns them in order
+func (u *pdfUpdate) writeCoverPages(root pdfRef, matches []MatchResult) []pdfRef {
+	mediaBox := [4]float64{0, 0, 841.92, 595.32} // A4 landscape, like the court's lists
+	for v := u.reader.Page(1).V; !v.IsNull(); v = v.Key("Parent") {
+		if box := v.Key("MediaBox"); box.Len() == 4 {
+			for i := range mediaBox {
+				mediaBox[i] = box.Index(i).Float64()
+			}
+			break
+		}
+	}
+	width, height := mediaBox[2]-mediaBox[0], mediaBox[3]-mediaBox[1]
+
+	// Capitals in Helvetica average about 0.6 em wide
+	columns := max(int((width-2*coverMargin)/(coverFontSize*0.6)), 20)
+	perPage := max(int((height-2*coverMargin-2*coverTitleSize)/coverLineHeight), 1)
+
+	// The original pages follow the cover pages, so their numbers depend on
+	// how many cover pages the list takes
+	var lines []string
+	for covers := 1; ; {
+		lines = coverLines(matches, covers, columns)
+		if n := (len(lines) + perPage - 1) / perPage; n != covers {
+			covers = n
+			continue
+		}
+		break
+	}
+
+	font := u.allocate()
+	u.writeObject(font, "<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>")
+
+	var pages []pdfRef
+	for start := 0; start < len(lines); start += perPage {
+		title := fmt.Sprintf("Matched matters (%d)", len(matches))
+		if start > 0 {
+			title += ", continued"
+		}
+
+		var content strings.Builder
+		top := mediaBox[3] - coverMargin - coverTitleSize
+		fmt.Fprintf(&content, "BT /F1 %s Tf 1 0 0 1 %s %s Tm %s Tj ET\n",
+			pdfNumber(coverTitleSize), pdfNumber(mediaBox[0]+coverMargin), pdfNumber(top), pdfTextString(title))
+		fmt.Fprintf(&content, "BT /F1 %s Tf %s TL 1 0 0 1 %s %s Tm\n",
+			pdfNumber(coverFontSize), pdfNumber(coverLineHeight), pdfNumber(mediaBox[0]+coverMargin), pdfNumber(top-2*coverTitleSize))
+		for _, line := range lines[start:min(start+perPage, len(lines))] {
+			fmt.Fprintf(&content, "%s Tj T*\n", pdfTextString(line))
+		}
+		content.WriteString("ET")
+
+		contents, page := u.allocate(), u.allocate()
+		u.writeStream(contents, "", []byte(content.String()))
+		u.writeObject(page, fmt.Sprintf(
+			"<< /Type /Page /Parent %s /MediaBox [%s %s %s %s] /Resources << /Font << /F1 %s >> >> /Contents %s >>",
+			root, pdfNumber(mediaBox[0]), pdfNumber(mediaBox[1]), pdfNumber(mediaBox[2]), pdfNumber(mediaBox[3]), font, contents))
+		pages = append(pages, page)
+	}
+	return pages
+}
+
+// coverLines lists the matches in lines of at most width characters, giving
+// the page each highlighted item is on when the original pages follow the
+// given number of cover pages
+func coverLines(matches []MatchResult, covers, width int) []string {
+	if len(matches) == 0 {
+		return []string{"No assigned matters were found in this cause list."}
+	}
+
+	var lines []string
+	for i, match := range matches {
+		if i > 0 {
+			lines = append(lines, "")
+		}
+		item := match.CauseListItem
+		where := "Not highlighted"
+		if source := ItemSource(item); source.Page > 0 && !source.BBox.IsZero() {
+			where = fmt.Sprintf("Page %d", source.Page+covers)
+		}
+		lines = append(lines, wrapText(fmt.Sprintf("%s: matter %d, %s %s, %s v %s",
+			where, item.GetMatterNumber(), ItemType(item), item.GetTenementNumber(),
+			item.GetApplyingParty(), item.GetRespondingParty()), width)...)
+		lines = append(lines, wrapText(fmt.Sprintf("    Client %s: %s", match.AssignedMatter.ClientName, match.MatchReason), width)...)
+	}
+	return lines
+}
+
+// wrapText breaks text into lines of at most width characters, at spaces
+// where possible
+func wrapText(text string, width int) []string {
+	var lines []string
+	for len(text) > width {
+		cut := strings.LastIndexByte(text[:width], ' ')
+		if cut <= 0 {
+			cut = width
+		}
+		lines = append(lines, text[:cut])
+		text = "    " + strings.TrimLeft(text[cut:], " ")
+	}
+	return append(lines, text)
+}
+
+// pdfTextString writes text as a literal string in the WinAnsi encoding of
+// the standard fonts. Characters it can't encode are written as "?".
+func pdfTextString(text string) string {
+	var b strings.Builder
+	b.WriteByte('(')
+	for _, r := range text {
+		switch {
+		case r == '(' || r == ')' || r == '\\':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case r < ' ':
+			b.WriteByte(' ')
+		case r <= '~':
+			b.WriteRune(r)
+		case r >= 0xa0 && r <= 0xff:
+			fmt.Fprintf(&b, "\\%03o", r)
+		case r == '–' || r == '—':
+			b.WriteByte('-')
+		default:
+			b.WriteByte('?')
+		}
+	}
+	b.WriteByte(')')
+	return b.String()
+}
+
+// pdfNumber writes a number with at most two decimal places
+func pdfNumber(f float64) string {
+	s := strconv.FormatFloat(f, 'f', 2, 64)
+	s = strings.TrimRight(strings.TrimRight(s, "0"), ".")
+	if s == "-0" {
+		return "0"
+	}
+	return s
+}
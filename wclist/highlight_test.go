@@ -0,0 +1,132 @@
+package wclist
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ledongthuc/pdf"
+)
+
+func TestWriteHighlightedPDF(t *testing.T) {
+	pages := []string{
+		textPage("WARDEN'S COURT KALGOORLIE"),
+		textPage("OBJECTIONS", "1", "698561", "KARORA (HIGGINSVILLE) PTY LTD", "E 15/2082", "FMG RESOURCES PTY LTD",
+			"2", "698562", "NORTHERN STAR RESOURCES LTD", "M 15/1830", "GOLDFIELDS PROSPECTING PTY LTD"),
+	}
+	original := minimalPDF(pages...)
+
+	read := func(data []byte) *CauseList {
+		cl := NewCauseList("Western Australia", "Kalgoorlie", time.Now())
+		if err := cl.ReadCauseListOptions(context.Background(), bytes.NewReader(data), int64(len(data)), DefaultReadOptions()); err != nil {
+			t.Fatalf("Failed to read cause list: %v", err)
+		}
+		return cl
+	}
+	highlight := func(data []byte, matches []MatchResult) []byte {
+		var out bytes.Buffer
+		if err := WriteHighlightedPDF(&out, bytes.NewReader(data), int64(len(data)), matches); err != nil {
+			t.Fatalf("Failed to write highlighted PDF: %v", err)
+		}
+		if !bytes.HasPrefix(out.Bytes(), data) {
+			t.Fatalf("Expected the original PDF to be kept unchanged")
+		}
+		return out.Bytes()
+	}
+
+	cl := read(original)
+	matches := cl.SearchAssignedMatters([]AssignedMatter{
+		{ClientName: "Karora (Higginsville) Pty Ltd", TenementNumber: "E 15/2082"},
+		{ClientName: "FMG Resources Pty Ltd"},
+	})
+	if len(matches) != 2 {
+		t.Fatalf("Expected both assigned matters to match item 1, got %d matches", len(matches))
+	}
+	data := highlight(original, matches)
+
+	reader, err := pdf.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatalf("Failed to open highlighted PDF: %v", err)
+	}
+
+	t.Run("Cover page", func(t *testing.T) {
+		if reader.NumPage() != 3 {
+			t.Fatalf("Expected a cover page before the 2 original pages, got %d pages", reader.NumPage())
+		}
+		text, err := reader.Page(1).GetPlainText(nil)
+		if err != nil {
+			t.Fatalf("Failed to read cover page: %v", err)
+		}
+		for _, want := range []string{"Matched matters (2)", "Page 3: matter 1, objection E 15/2082", "Client FMG Resources Pty Ltd: "} {
+			if !strings.Contains(text, want) {
+				t.Fatalf("Expected the cover page to contain %q, got %q", want, text)
+			}
+		}
+	})
+
+	t.Run("Highlights", func(t *testing.T) {
+		annots := reader.Page(3).V.Key("Annots")
+		if annots.Len() != 1 {
+			t.Fatalf("Expected one highlight for the item matched twice, got %d annotations", annots.Len())
+		}
+		annot := annots.Index(0)
+		if annot.Key("Subtype").Name() != "Highlight" {
+			t.Fatalf("Expected a highlight annotation, got %v", annot)
+		}
+		box := ItemSource(cl.Items[0]).BBox
+		rect := annot.Key("Rect")
+		if rect.Index(0).Float64() > box.X0 || rect.Index(1).Float64() > box.Y0 || rect.Index(3).Float64() < box.Y1 {
+			t.Fatalf("Expected the highlight to cover %+v, got %v", box, rect)
+		}
+		if contents := annot.Key("Contents").Text(); !strings.Contains(contents, "Karora (Higginsville) Pty Ltd: ") ||
+			!strings.Contains(contents, "FMG Resources Pty Ltd: ") {
+			t.Fatalf("Expected the highlight to name both clients, got %q", contents)
+		}
+		if reader.Page(2).V.Key("Annots").Len() != 0 {
+			t.Fatalf("Expected no highlights on the first page")
+		}
+	})
+
+	t.Run("Still a cause list", func(t *testing.T) {
+		if got := read(data); len(got.Items) != len(cl.Items) {
+			t.Fatalf("Expected the highlighted PDF to read as the same %d items, got %d", len(cl.Items), len(got.Items))
+		}
+	})
+
+	t.Run("Highlighting twice", func(t *testing.T) {
+		again := highlight(data, read(data).SearchAssignedMatters([]AssignedMatter{{ClientName: "Northern Star Resources Ltd"}}))
+		reader, err := pdf.NewReader(bytes.NewReader(again), int64(len(again)))
+		if err != nil {
+			t.Fatalf("Failed to open PDF highlighted twice: %v", err)
+		}
+		if reader.NumPage() != 4 || reader.Page(4).V.Key("Annots").Len() != 2 {
+			t.Fatalf("Expected a second cover page and the earlier highlight kept, got %d pages", reader.NumPage())
+		}
+	})
+
+	t.Run("Object streams", func(t *testing.T) {
+		original := objectStreamPDF(t)
+		data := highlight(original, nil)
+		reader, err := pdf.NewReader(bytes.NewReader(data), int64(len(data)))
+		if err != nil {
+			t.Fatalf("Failed to open highlighted PDF: %v", err)
+		}
+		if reader.NumPage() != 2 {
+			t.Fatalf("Expected a cover page before the page read from the object stream, got %d pages", reader.NumPage())
+		}
+		if text, err := reader.Page(2).GetPlainText(nil); err != nil || !strings.Contains(text, "KALGOORLIE") {
+			t.Fatalf("Expected the original page after the cover, got %q (%v)", text, err)
+		}
+	})
+
+	t.Run("Encrypted", func(t *testing.T) {
+		encrypted := buildTestPDF(newTestPDFEncryption("", "owner"), pages, nil)
+		err := WriteHighlightedPDF(&bytes.Buffer{}, bytes.NewReader(encrypted), int64(len(encrypted)), nil)
+		if !errors.Is(err, ErrEncryptedPDF) {
+			t.Fatalf("Expected ErrEncryptedPDF, got %v", err)
+		}
+	})
+}
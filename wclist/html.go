@@ -0,0 +1,112 @@
+package wclist
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// htmlSource reads cause lists published as web pages with HTML tables
+type htmlSource struct{}
+
+func (htmlSource) Name() string { return "html" }
+
+func (htmlSource) Detect(r io.ReaderAt, size int64) bool {
+	return strings.HasPrefix(sniffContentType(r, size), "text/html")
+}
+
+func (htmlSource) Read(ctx context.Context, cl *CauseList, r io.ReaderAt, size int64, opts ReadOptions, report *ParseReport) ([]CauseListItem, error) {
+	doc, err := html.Parse(io.NewSectionReader(r, 0, size))
+	if err != nil {
+		return nil, err
+	}
+	return cl.itemsFromTables(ctx, htmlTables(doc), opts, report)
+}
+
+// htmlTables collects the tables in a document along with the text of the
+// heading or paragraph closest before each one
+func htmlTables(doc *html.Node) []sourceTable {
+	var tables []sourceTable
+	var heading string
+	var preamble []string
+
+	var walk func(n *html.Node)
+	walk = func(n *html.Node) {
+		if n.Type == html.ElementNode {
+			switch n.Data {
+			case "table":
+				tables = append(tables, sourceTable{Heading: heading, Preamble: strings.Join(preamble, "\n"), Rows: htmlRows(n)})
+				preamble = nil
+				return
+			case "h1", "h2", "h3", "h4", "h5", "h6", "p", "caption":
+				if text := cleanCellText(htmlText(n)); text != "" {
+					heading = text
+					preamble = append(preamble, text)
+				}
+				return
+			}
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			walk(c)
+		}
+	}
+	walk(doc)
+
+	return tables
+}
+
+// htmlRows returns the cell text of each row in a table, ignoring nested tables
+func htmlRows(table *html.Node) [][]string {
+	var rows [][]string
+
+	var walk func(n *html.Node)
+	walk = func(n *html.Node) {
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			if c.Type != html.ElementNode {
+				continue
+			}
+			switch c.Data {
+			case "table":
+				continue
+			case "tr":
+				var row []string
+				for cell := c.FirstChild; cell != nil; cell = cell.NextSibling {
+					if cell.Type == html.ElementNode && (cell.Data == "td" || cell.Data == "th") {
+						row = append(row, cleanCellText(htmlText(cell)))
+					}
+				}
+				rows = append(rows, row)
+			default:
+				walk(c)
+			}
+		}
+	}
+	walk(table)
+
+	return rows
+}
+
+// htmlText returns the text content of a node, treating line breaks and
+// block elements as spaces
+func htmlText(n *html.Node) string {
+	var buf bytes.Buffer
+
+	var walk func(n *html.Node)
+	walk = func(n *html.Node) {
+		switch {
+		case n.Type == html.TextNode:
+			buf.WriteString(n.Data)
+		case n.Type == html.ElementNode && (n.Data == "br" || n.Data == "p" || n.Data == "div"):
+			buf.WriteString(" ")
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			walk(c)
+		}
+	}
+	walk(n)
+
+	return buf.String()
+}
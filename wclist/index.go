@@ -0,0 +1,271 @@
+package wclist
+
+import (
+	"slices"
+	"sort"
+	"strings"
+)
+
+// Match reasons reported in MatchResult.MatchReason
+const (
+	reasonTenement             = "Tenement number match"
+	reasonClientApplying       = "Client name matches applying party"
+	reasonClientResponding     = "Client name matches responding party"
+	reasonOtherPartyApplying   = "Other party matches applying party"
+	reasonOtherPartyResponding = "Other party matches responding party"
+)
+
+// SearchIndex is a lookup structure over the items of a cause list.
+// It maps tenement numbers, the normalised names of each party and the
+// trigrams of those names to item positions so that searches only inspect
+// items that can possibly match.
+//
+// Names match as in a linear search: a name matches a party when either
+// contains the other, so "smith" matches "smithson".
+type SearchIndex struct {
+	items      []CauseListItem
+	tenements  map[string][]int
+	names      map[string][]int
+	grams      map[string][]int // Trigrams of each party name
+	nameLens   []int            // Lengths of the indexed names, shortest first
+	applying   [][]string       // Normalised names of each item's applying parties
+	responding [][]string
+}
+
+// gramSize is the length of the substrings names are indexed by
+const gramSize = 3
+
+// NewSearchIndex builds a search index over the given items
+func NewSearchIndex(items []CauseListItem) *SearchIndex {
+	idx := &SearchIndex{
+		items:      items,
+		tenements:  make(map[string][]int),
+		names:      make(map[string][]int),
+		grams:      make(map[string][]int),
+		applying:   make([][]string, len(items)),
+		responding: make([][]string, len(items)),
+	}
+
+	for i, item := range items {
+		if tenement := item.GetTenementNumber(); tenement != "" {
+			key := strings.ToUpper(tenement)
+			idx.tenements[key] = append(idx.tenements[key], i)
+		}
+
+		idx.applying[i], idx.responding[i] = partyNames(item)
+
+		for _, name := range slices.Concat(idx.applying[i], idx.responding[i]) {
+			if _, ok := idx.names[name]; !ok {
+				idx.nameLens = append(idx.nameLens, len(name))
+			}
+			addPosting(idx.names, name, i)
+			for start := 0; start+gramSize <= len(name); start++ {
+				addPosting(idx.grams, name[start:start+gramSize], i)
+			}
+		}
+	}
+	slices.Sort(idx.nameLens)
+	idx.nameLens = slices.Compact(idx.nameLens)
+
+	return idx
+}
+
+// addPosting records item position i under key. Items are indexed in
+// order, so a duplicate can only be the last entry.
+func addPosting(postings map[string][]int, key string, i int) {
+	list := postings[key]
+	if len(list) > 0 && list[len(list)-1] == i {
+		return
+	}
+	postings[key] = append(list, i)
+}
+
+// Len returns the number of indexed items
+func (idx *SearchIndex) Len() int {
+	return len(idx.items)
+}
+
+// Search finds the indexed items matching each assigned matter.
+// Results are ordered by assigned matter and then by item position,
+// the same order as a linear scan of the cause list.
+func (idx *SearchIndex) Search(assignedMatters []AssignedMatter) []MatchResult {
+	var results []MatchResult
+
+	for _, assignedMatter := range assignedMatters {
+		client := normalizeName(assignedMatter.ClientName)
+		others := make([]string, len(assignedMatter.OtherPartyNames))
+		for i, otherParty := range assignedMatter.OtherPartyNames {
+			others[i] = normalizeName(otherParty)
+		}
+
+		for _, i := range idx.candidates(assignedMatter, client, others) {
+			if match, reason := idx.isMatch(assignedMatter, client, others, i); match {
+				results = append(results, MatchResult{
+					AssignedMatter: assignedMatter,
+					CauseListItem:  idx.items[i],
+					MatchReason:    reason,
+				})
+			}
+		}
+	}
+
+	return results
+}
+
+// candidates returns the sorted positions of items that share a tenement
+// number with the assigned matter or whose parties can match its names
+func (idx *SearchIndex) candidates(assignedMatter AssignedMatter, client string, others []string) []int {
+	seen := make(map[int]struct{})
+	add := func(postings []int) {
+		for _, i := range postings {
+			seen[i] = struct{}{}
+		}
+	}
+
+	if assignedMatter.TenementNumber != "" {
+		add(idx.tenements[strings.ToUpper(assignedMatter.TenementNumber)])
+	}
+	if assignedMatter.ClientName != "" {
+		idx.addNameCandidates(client, add)
+	}
+	for i, otherParty := range assignedMatter.OtherPartyNames {
+		if otherParty != "" {
+			idx.addNameCandidates(others[i], add)
+		}
+	}
+
+	positions := make([]int, 0, len(seen))
+	for i := range seen {
+		positions = append(positions, i)
+	}
+	sort.Ints(positions)
+	return positions
+}
+
+// addNameCandidates adds the items whose parties contain name, and the
+// items whose party is a substring of name
+func (idx *SearchIndex) addNameCandidates(name string, add func([]int)) {
+	if name == "" {
+		return
+	}
+
+	// Party contains the name: intersect the postings of its trigrams,
+	// rarest first. Names too short to have a trigram are compared with
+	// every item.
+	if len(name) < gramSize {
+		all := make([]int, len(idx.items))
+		for i := range all {
+			all[i] = i
+		}
+		add(all)
+		return
+	}
+	var lists [][]int
+	for start := 0; start+gramSize <= len(name); start++ {
+		lists = append(lists, idx.grams[name[start:start+gramSize]])
+	}
+	sort.Slice(lists, func(i, j int) bool { return len(lists[i]) < len(lists[j]) })
+	common := lists[0]
+	for _, list := range lists[1:] {
+		if len(common) == 0 {
+			break
+		}
+		common = intersectPostings(common, list)
+	}
+	add(common)
+
+	// Name contains the party: look up every substring of the name as long
+	// as an indexed name
+	for _, length := range idx.nameLens {
+		if length > len(name) {
+			break
+		}
+		for start := 0; start+length <= len(name); start++ {
+			add(idx.names[name[start:start+length]])
+		}
+	}
+}
+
+// intersectPostings returns the positions present in both sorted lists
+func intersectPostings(a, b []int) []int {
+	var out []int
+	for i, j := 0, 0; i < len(a) && j < len(b); {
+		switch {
+		case a[i] < b[j]:
+			i++
+		case a[i] > b[j]:
+			j++
+		default:
+			out = append(out, a[i])
+			i++
+			j++
+		}
+	}
+	return out
+}
+
+// isMatch applies the same rules as CauseList.isMatch using the
+// pre-normalised names held in the index
+func (idx *SearchIndex) isMatch(assignedMatter AssignedMatter, client string, others []string, i int) (bool, string) {
+	item := idx.items[i]
+
+	// Primary match: tenement number
+	if assignedMatter.TenementNumber != "" &&
+		strings.EqualFold(assignedMatter.TenementNumber, item.GetTenementNumber()) {
+		return true, reasonTenement
+	}
+
+	// Secondary match: client name matches applying or responding party
+	if assignedMatter.ClientName != "" {
+		if anyNameMatches(client, idx.applying[i]) {
+			return true, reasonClientApplying
+		}
+		if anyNameMatches(client, idx.responding[i]) {
+			return true, reasonClientResponding
+		}
+	}
+
+	// Tertiary match: other party names
+	for j, otherParty := range assignedMatter.OtherPartyNames {
+		if otherParty != "" {
+			if anyNameMatches(others[j], idx.applying[i]) {
+				return true, reasonOtherPartyApplying
+			}
+			if anyNameMatches(others[j], idx.responding[i]) {
+				return true, reasonOtherPartyResponding
+			}
+		}
+	}
+
+	return false, ""
+}
+
+// Index returns the search index for the cause list, building it on first
+// use. The index is rebuilt automatically when Items is appended to or
+// replaced; call ResetIndex after editing items in place.
+func (cl *CauseList) Index() *SearchIndex {
+	cl.indexMu.Lock()
+	defer cl.indexMu.Unlock()
+
+	if cl.index == nil || cl.indexStale() {
+		cl.index = NewSearchIndex(cl.Items)
+	}
+	return cl.index
+}
+
+// ResetIndex discards the cached search index
+func (cl *CauseList) ResetIndex() {
+	cl.indexMu.Lock()
+	defer cl.indexMu.Unlock()
+
+	cl.index = nil
+}
+
+// indexStale reports whether Items has changed since the index was built
+func (cl *CauseList) indexStale() bool {
+	indexed := cl.index.items
+	if len(indexed) != len(cl.Items) {
+		return true
+	}
+	return len(indexed) > 0 && &indexed[0] != &cl.Items[0]
+}
@@ -0,0 +1,131 @@
+package wclist
+
+import (
+	"fmt"
+	"math/rand"
+	"reflect"
+	"testing"
+	"time"
+)
+
+var syntheticCompanies = []string{
+	"FMG RESOURCES PTY LTD", "BEACON MINERALS LIMITED", "FOCUS MINERALS LTD",
+	"KARORA (HIGGINSVILLE) PTY LTD", "EVOLUTION MINING (MUNGARI) PTY LTD",
+	"LAMERTON PTY LTD, GEODA PTY LTD", "MCCLAREN, Kym Anthony",
+	"WEST AUSTRALIAN PROSPECTORS PTY LTD", "SILVER LAKE (INTEGRA) PTY LIMITED",
+}
+
+// syntheticCauseList builds a deterministic cause list with n items
+func syntheticCauseList(n int) *CauseList {
+	rng := rand.New(rand.NewSource(1))
+	cl := NewCauseList("Warden's Court", "Kalgoorlie", time.Date(2025, 6, 24, 0, 0, 0, 0, time.UTC))
+
+	for i := 0; i < n; i++ {
+		base := CLIItems{
+			MatterNumber:   uint64(i + 1),
+			TenementNumber: fmt.Sprintf("E %d/%d", 15+rng.Intn(60), rng.Intn(5000)),
+		}
+		applicant := fmt.Sprintf("%s %d", syntheticCompanies[rng.Intn(len(syntheticCompanies))], rng.Intn(n))
+		other := fmt.Sprintf("HOLDINGS %d PTY LTD", rng.Intn(n))
+
+		switch i % 3 {
+		case 0:
+			cl.Items = append(cl.Items, ObjectionItems{CLIItems: base, ObjectionNumber: uint64(600000 + i), ObjectorName: other, ApplicantName: applicant})
+		case 1:
+			cl.Items = append(cl.Items, ForfeitureItems{CLIItems: base, ApplicantName: other, RespondentName: applicant})
+		default:
+			cl.Items = append(cl.Items, ExemptionItems{CLIItems: base, ApplicantName: applicant, RespondentName: other})
+		}
+		// Parsed items carry their parties
+		cl.Items[i] = withParties(cl.Items[i])
+	}
+
+	return cl
+}
+
+func syntheticAssignedMatters(n int) []AssignedMatter {
+	return []AssignedMatter{
+		{ClientName: "Karorra (Higginsville) Pty Ltd", TenementNumber: "E 15/2082"},
+		{ClientName: "FOCUS MINERALS LTD", OtherPartyNames: []string{"Jones Mining", "Brown Resources"}},
+		{TenementNumber: "e 40/1234"},
+		{ClientName: fmt.Sprintf("Holdings %d Pty Ltd", n/2)},
+		{ClientName: "Nobody In Particular"},
+	}
+}
+
+func TestSearchIndex(t *testing.T) {
+	cl := syntheticCauseList(2000)
+	matters := syntheticAssignedMatters(2000)
+
+	t.Run("Matches linear search", func(t *testing.T) {
+		want := cl.searchLinear(matters)
+		got := cl.SearchAssignedMatters(matters)
+		if len(want) == 0 {
+			t.Fatal("Expected the synthetic list to produce matches")
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Fatalf("Indexed search returned %d matches, linear search returned %d", len(got), len(want))
+		}
+	})
+
+	t.Run("Matches linear search on partial names", func(t *testing.T) {
+		partial := []AssignedMatter{
+			{ClientName: "McClar"},                                    // Within a word
+			{ClientName: "Holdings 1"},                                // Prefix of "HOLDINGS 1..." numbers
+			{ClientName: "tralian prospect"},                          // Across words
+			{ClientName: "Lamerton Pty Ltd Geoda Pty Ltd and Others"}, // Contains a party
+			{ClientName: "Al"},                                        // Shorter than a trigram
+		}
+		want := cl.searchLinear(partial)
+		got := cl.SearchAssignedMatters(partial)
+		if len(want) == 0 {
+			t.Fatal("Expected partial names to produce matches")
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Fatalf("Indexed search returned %d matches, linear search returned %d", len(got), len(want))
+		}
+	})
+
+	t.Run("Rebuilds after items change", func(t *testing.T) {
+		before := cl.Index()
+		cl.Items = append(cl.Items, ForfeitureItems{
+			CLIItems:       CLIItems{MatterNumber: 9999, TenementNumber: "M 24/37"},
+			ApplicantName:  "VAN BLITTERSWYK, Wayne Craig",
+			RespondentName: "GARDNER, Robert Charles",
+		})
+		if cl.Index() == before {
+			t.Fatal("Expected the index to be rebuilt after appending an item")
+		}
+
+		matches := cl.SearchAssignedMatters([]AssignedMatter{{ClientName: "Gardner Robert Charles"}})
+		if len(matches) != 1 || matches[0].MatchReason != reasonClientResponding {
+			t.Fatalf("Expected one responding party match, got %+v", matches)
+		}
+	})
+}
+
+func BenchmarkSearchAssignedMatters(b *testing.B) {
+	const n = 100000
+	cl := syntheticCauseList(n)
+	matters := syntheticAssignedMatters(n)
+
+	b.Run("Linear", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			cl.searchLinear(matters)
+		}
+	})
+
+	b.Run("Indexed", func(b *testing.B) {
+		idx := cl.Index()
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			idx.Search(matters)
+		}
+	})
+
+	b.Run("BuildIndex", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			NewSearchIndex(cl.Items)
+		}
+	})
+}
@@ -0,0 +1,133 @@
+package wclist
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// SchemaVersion is the version of the JSON encoding of a CauseList.
+// It is increased whenever the encoding changes incompatibly.
+const SchemaVersion = 1
+
+// causeListJSON is the JSON encoding of a CauseList
+type causeListJSON struct {
+	SchemaVersion int        `json:"schema_version"`
+	Jurisdiction  string     `json:"jurisdiction"`
+	Warden        string     `json:"warden"`
+	ReleaseDate   time.Time  `json:"release_date"`
+	Location      string     `json:"location,omitempty"`
+	SittingDate   time.Time  `json:"sitting_date,omitzero"`
+	ParserVersion int        `json:"parser_version,omitempty"`
+	Items         []itemJSON `json:"items"`
+}
+
+// itemJSON wraps an item with the type needed to decode it. The parties
+// and validation are included for readers of the JSON and are ignored when
+// decoding.
+type itemJSON struct {
+	Type       string          `json:"type"`
+	Item       json.RawMessage `json:"item"`
+	Parties    []Party         `json:"parties,omitempty"`
+	Validation *Validation     `json:"validation,omitempty"`
+}
+
+// MarshalItem encodes an item together with its type
+func MarshalItem(item CauseListItem) ([]byte, error) {
+	encoded, err := encodeItem(item)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(encoded)
+}
+
+// UnmarshalItem decodes an item encoded by MarshalItem
+func UnmarshalItem(data []byte) (CauseListItem, error) {
+	var encoded itemJSON
+	if err := json.Unmarshal(data, &encoded); err != nil {
+		return nil, err
+	}
+	return decodeItem(encoded)
+}
+
+func encodeItem(item CauseListItem) (itemJSON, error) {
+	itemType := ItemType(item)
+	if _, ok := lookupSectionParser(itemType); !ok {
+		return itemJSON{}, fmt.Errorf("cannot encode cause list item of type %T", item)
+	}
+
+	data, err := json.Marshal(item)
+	if err != nil {
+		return itemJSON{}, err
+	}
+	validation := ValidateItem(item)
+	return itemJSON{Type: itemType, Item: data, Parties: ItemParties(item), Validation: &validation}, nil
+}
+
+func decodeItem(encoded itemJSON) (CauseListItem, error) {
+	parser, ok := lookupSectionParser(encoded.Type)
+	if !ok {
+		return nil, fmt.Errorf("unknown cause list item type %q", encoded.Type)
+	}
+
+	item, err := parser.DecodeItem(encoded.Item)
+	if err != nil {
+		return nil, fmt.Errorf("decoding %s item: %w", encoded.Type, err)
+	}
+	return item, nil
+}
+
+// MarshalJSON encodes the cause list with a schema version and the type of each item
+func (cl *CauseList) MarshalJSON() ([]byte, error) {
+	encoded := causeListJSON{
+		SchemaVersion: SchemaVersion,
+		Jurisdiction:  cl.Jurisdiction,
+		Warden:        cl.Warden,
+		ReleaseDate:   cl.ReleaseDate,
+		Location:      cl.Location,
+		SittingDate:   cl.SittingDate,
+		ParserVersion: cl.ParserVersion,
+		Items:         make([]itemJSON, 0, len(cl.Items)),
+	}
+
+	for _, item := range cl.Items {
+		encodedItem, err := encodeItem(item)
+		if err != nil {
+			return nil, err
+		}
+		encoded.Items = append(encoded.Items, encodedItem)
+	}
+
+	return json.Marshal(encoded)
+}
+
+// UnmarshalJSON decodes a cause list encoded by MarshalJSON. Encodings from
+// a newer schema version than this package supports are rejected.
+func (cl *CauseList) UnmarshalJSON(data []byte) error {
+	var encoded causeListJSON
+	if err := json.Unmarshal(data, &encoded); err != nil {
+		return err
+	}
+
+	if encoded.SchemaVersion < 1 || encoded.SchemaVersion > SchemaVersion {
+		return fmt.Errorf("unsupported cause list schema version %d", encoded.SchemaVersion)
+	}
+
+	items := make([]CauseListItem, 0, len(encoded.Items))
+	for i, encodedItem := range encoded.Items {
+		item, err := decodeItem(encodedItem)
+		if err != nil {
+			return fmt.Errorf("item %d: %w", i, err)
+		}
+		items = append(items, item)
+	}
+
+	cl.Jurisdiction = encoded.Jurisdiction
+	cl.Warden = encoded.Warden
+	cl.ReleaseDate = encoded.ReleaseDate
+	cl.Location = encoded.Location
+	cl.SittingDate = encoded.SittingDate
+	cl.ParserVersion = encoded.ParserVersion
+	cl.Items = items
+	return nil
+}
@@ -0,0 +1,63 @@
+package wclist
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestCauseListJSON(t *testing.T) {
+	cl := syntheticCauseList(30)
+	cl.ParserVersion = ParserVersion
+	cl.Location = "Kalgoorlie"
+
+	t.Run("Round trip", func(t *testing.T) {
+		data, err := json.Marshal(cl)
+		if err != nil {
+			t.Fatalf("Failed to marshal cause list: %v", err)
+		}
+
+		var decoded CauseList
+		if err := json.Unmarshal(data, &decoded); err != nil {
+			t.Fatalf("Failed to unmarshal cause list: %v", err)
+		}
+		if !reflect.DeepEqual(decoded.Items, cl.Items) {
+			t.Fatal("Decoded items differ from the original items")
+		}
+		if !decoded.ReleaseDate.Equal(cl.ReleaseDate) || decoded.Warden != cl.Warden || decoded.ParserVersion != ParserVersion ||
+			decoded.Sitting() != cl.Sitting() {
+			t.Fatalf("Decoded header differs: %+v by parser %d", decoded.Sitting(), decoded.ParserVersion)
+		}
+	})
+
+	t.Run("Single item", func(t *testing.T) {
+		data, err := MarshalItem(cl.Items[0])
+		if err != nil {
+			t.Fatalf("Failed to marshal item: %v", err)
+		}
+		if !strings.Contains(string(data), `"type":"objection"`) {
+			t.Fatalf("Expected a type discriminator, got %s", data)
+		}
+		item, err := UnmarshalItem(data)
+		if err != nil {
+			t.Fatalf("Failed to unmarshal item: %v", err)
+		}
+		if !reflect.DeepEqual(item, cl.Items[0]) {
+			t.Fatalf("Expected %+v, got %+v", cl.Items[0], item)
+		}
+	})
+
+	t.Run("Rejects unsupported input", func(t *testing.T) {
+		for _, data := range []string{
+			`{"schema_version": 99, "items": []}`,
+			`{"items": []}`,
+			`{"schema_version": 1, "items": [{"type": "caveat", "item": {}}]}`,
+		} {
+			var decoded CauseList
+			if err := json.Unmarshal([]byte(data), &decoded); err == nil {
+				t.Errorf("Expected an error decoding %s", data)
+			}
+		}
+	})
+}
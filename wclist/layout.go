@@ -0,0 +1,257 @@
+package wclist
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ledongthuc/pdf"
+)
+
+// pageLayout reads the text of a PDF page in the same way as GetPlainText,
+// along with the area each line of the text covers on the page, so that
+// boxes[i] is the box of line i+1 of the text.
+//
+// The PDF reader doesn't report the widths of most embedded fonts, so glyphs
+// without a width are taken to be half as wide as the font size. Boxes are
+// good enough to highlight a row, not to measure it.
+func pageLayout(page pdf.Page) (text string, boxes []BBox, err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			text, boxes, err = "", nil, errors.New(fmt.Sprint(p))
+		}
+	}()
+
+	if page.V.IsNull() || page.V.Key("Contents").Kind() == pdf.Null {
+		return "", nil, nil
+	}
+
+	fonts := make(map[string]pdf.Font)
+	for _, name := range page.Fonts() {
+		fonts[name] = page.Font(name)
+	}
+
+	var builder strings.Builder
+	boxes = []BBox{{}}
+	write := func(s string, box BBox) {
+		builder.WriteString(s)
+		boxes[len(boxes)-1] = boxes[len(boxes)-1].Union(box)
+		for range strings.Count(s, "\n") {
+			boxes = append(boxes, BBox{})
+		}
+	}
+
+	g := layoutState{th: 1, ctm: identityMatrix}
+	var stack []layoutState
+	var font pdf.Font
+	var enc pdf.TextEncoding = nopTextEncoding{}
+
+	show := func(raw string) {
+		n := 0 // Widths are looked up by byte, as the PDF reader does
+		for _, ch := range enc.Decode(raw) {
+			width := 0.0
+			if n < len(raw) {
+				width = font.Width(int(raw[n]))
+			}
+			n++
+			if width == 0 {
+				width = 500 // Half an em, in thousandths
+			}
+			write(string(ch), g.glyphBox(width/1000))
+
+			advance := (width/1000*g.tfs + g.tc) * g.th
+			if ch == ' ' {
+				advance += g.tw * g.th
+			}
+			g.tm = translation(advance, 0).mul(g.tm)
+		}
+	}
+	nextLine := func() {
+		g.tlm = translation(0, -g.tl).mul(g.tlm)
+		g.tm = g.tlm
+	}
+
+	pdf.Interpret(page.V.Key("Contents"), func(stk *pdf.Stack, op string) {
+		args := make([]pdf.Value, stk.Len())
+		for i := len(args) - 1; i >= 0; i-- {
+			args[i] = stk.Pop()
+		}
+
+		switch op {
+		case "cm":
+			if len(args) == 6 {
+				g.ctm = matrixFrom(args).mul(g.ctm)
+			}
+		case "q":
+			stack = append(stack, g)
+		case "Q":
+			if n := len(stack) - 1; n >= 0 {
+				g, stack = stack[n], stack[:n]
+			}
+		case "BT":
+			g.tm, g.tlm = identityMatrix, identityMatrix
+			write("\n", BBox{})
+		case "Tf":
+			if len(args) == 2 {
+				font = fonts[args[0].Name()]
+				if enc = font.Encoder(); enc == nil {
+					enc = nopTextEncoding{}
+				}
+				g.tfs = args[1].Float64()
+			}
+		case "Tc":
+			if len(args) == 1 {
+				g.tc = args[0].Float64()
+			}
+		case "Tw":
+			if len(args) == 1 {
+				g.tw = args[0].Float64()
+			}
+		case "Tz":
+			if len(args) == 1 {
+				g.th = args[0].Float64() / 100
+			}
+		case "TL":
+			if len(args) == 1 {
+				g.tl = args[0].Float64()
+			}
+		case "Ts":
+			if len(args) == 1 {
+				g.trise = args[0].Float64()
+			}
+		case "TD", "Td":
+			if len(args) == 2 {
+				if op == "TD" {
+					g.tl = -args[1].Float64()
+				}
+				g.tlm = translation(args[0].Float64(), args[1].Float64()).mul(g.tlm)
+				g.tm = g.tlm
+			}
+		case "Tm":
+			if len(args) == 6 {
+				g.tlm = matrixFrom(args)
+				g.tm = g.tlm
+			}
+		case "T*":
+			nextLine()
+			// GetPlainText decodes the line break with the current font
+			for _, ch := range enc.Decode("\n") {
+				write(string(ch), BBox{})
+			}
+		case "'", "\"":
+			if len(args) == 3 {
+				g.tw, g.tc = args[0].Float64(), args[1].Float64()
+			}
+			if len(args) > 0 {
+				nextLine()
+				show(args[len(args)-1].RawString())
+			}
+		case "Tj":
+			if len(args) == 1 {
+				show(args[0].RawString())
+			}
+		case "TJ":
+			if len(args) != 1 {
+				return
+			}
+			for i := 0; i < args[0].Len(); i++ {
+				if x := args[0].Index(i); x.Kind() == pdf.String {
+					show(x.RawString())
+				} else {
+					g.tm = translation(-x.Float64()/1000*g.tfs*g.th, 0).mul(g.tm)
+				}
+			}
+		}
+	})
+
+	return builder.String(), boxes, nil
+}
+
+// withRowBoxes sets the box of each item's row from the layout of the page
+// its text was read from. Items are left without a box when the layout
+// doesn't reproduce the text, and the page is recorded in the report.
+func withRowBoxes(page pdf.Page, num int, content string, items []CauseListItem, report *ParseReport) []CauseListItem {
+	text, boxes, err := pageLayout(page)
+	if err != nil || text != content {
+		report.UnboxedPages = append(report.UnboxedPages, num)
+		return items
+	}
+
+	for i, item := range items {
+		source := ItemSource(item)
+		if source.FirstLine == 0 || source.LastLine > len(boxes) {
+			continue
+		}
+		for _, box := range boxes[source.FirstLine-1 : source.LastLine] {
+			source.BBox = source.BBox.Union(box)
+		}
+		items[i] = withSource(item, source)
+	}
+	return items
+}
+
+// layoutState is the part of the graphics state that positions text
+type layoutState struct {
+	tc, tw, th, tl, tfs, trise float64
+	tm, tlm, ctm               layoutMatrix
+}
+
+// glyphBox returns the box of a glyph of the given width, in ems, drawn at
+// the current text position. The box runs from a little below the baseline
+// to the font size above it.
+func (g layoutState) glyphBox(width float64) BBox {
+	trm := layoutMatrix{{g.tfs * g.th, 0, 0}, {0, g.tfs, 0}, {0, g.trise, 1}}.mul(g.tm).mul(g.ctm)
+
+	var box BBox
+	for i, corner := range [][2]float64{{0, -0.2}, {width, -0.2}, {0, 0.8}, {width, 0.8}} {
+		x, y := trm.apply(corner[0], corner[1])
+		if i == 0 {
+			box = BBox{X0: x, Y0: y, X1: x, Y1: y}
+			continue
+		}
+		box = BBox{X0: min(box.X0, x), Y0: min(box.Y0, y), X1: max(box.X1, x), Y1: max(box.Y1, y)}
+	}
+	return box
+}
+
+// layoutMatrix is a PDF transformation matrix, with the translation in the
+// bottom row
+type layoutMatrix [3][3]float64
+
+var identityMatrix = layoutMatrix{{1, 0, 0}, {0, 1, 0}, {0, 0, 1}}
+
+func translation(tx, ty float64) layoutMatrix {
+	return layoutMatrix{{1, 0, 0}, {0, 1, 0}, {tx, ty, 1}}
+}
+
+// matrixFrom reads a matrix from the six operands of cm or Tm
+func matrixFrom(args []pdf.Value) layoutMatrix {
+	return layoutMatrix{
+		{args[0].Float64(), args[1].Float64(), 0},
+		{args[2].Float64(), args[3].Float64(), 0},
+		{args[4].Float64(), args[5].Float64(), 1},
+	}
+}
+
+func (m layoutMatrix) mul(n layoutMatrix) layoutMatrix {
+	var out layoutMatrix
+	for i := range 3 {
+		for j := range 3 {
+			for k := range 3 {
+				out[i][j] += m[i][k] * n[k][j]
+			}
+		}
+	}
+	return out
+}
+
+// apply transforms the point (x, y)
+func (m layoutMatrix) apply(x, y float64) (float64, float64) {
+	return x*m[0][0] + y*m[1][0] + m[2][0], x*m[0][1] + y*m[1][1] + m[2][1]
+}
+
+// nopTextEncoding reads text in fonts without an encoding byte for byte, as
+// the PDF reader does
+type nopTextEncoding struct{}
+
+func (nopTextEncoding) Decode(raw string) string { return raw }
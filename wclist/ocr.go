@@ -0,0 +1,91 @@
+package wclist
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"regexp"
+	"strings"
+)
+
+// OCR recognises the text in the image of a scanned page
+type OCR interface {
+	Recognize(ctx context.Context, image PageImage) (string, error)
+}
+
+// PageImage is an image embedded in a PDF page
+type PageImage struct {
+	Page   int
+	Format string // Image file format: "jpeg", "jp2", "png" or "tiff"
+	Data   []byte // Image file contents
+	Width  int
+	Height int
+}
+
+// TesseractOCR recognises text by running a locally installed tesseract
+type TesseractOCR struct {
+	Path     string   // Path to the tesseract binary; defaults to "tesseract" on the PATH
+	Language string   // Language to recognise; defaults to "eng"
+	Args     []string // Extra arguments, such as "--psm", "6"
+}
+
+// Recognize writes the image to a temporary file and returns tesseract's text output
+func (t TesseractOCR) Recognize(ctx context.Context, image PageImage) (string, error) {
+	path := t.Path
+	if path == "" {
+		path = "tesseract"
+	}
+	language := t.Language
+	if language == "" {
+		language = "eng"
+	}
+
+	file, err := os.CreateTemp("", "wclist-page-*."+image.Format)
+	if err != nil {
+		return "", err
+	}
+	defer os.Remove(file.Name())
+	if _, err := file.Write(image.Data); err != nil {
+		file.Close()
+		return "", err
+	}
+	if err := file.Close(); err != nil {
+		return "", err
+	}
+
+	args := append([]string{file.Name(), "stdout", "-l", language}, t.Args...)
+	cmd := exec.CommandContext(ctx, path, args...)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("running tesseract: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	return string(out), nil
+}
+
+// ocrMatterNumberPattern matches a matter number at the start of an OCR line
+var ocrMatterNumberPattern = regexp.MustCompile(`(?m)^\s*(\d{1,3})\s+`)
+
+// splitOCRRows puts the matter number at the start of each OCR line on a line
+// of its own. OCR reads a table row as one line, whereas PDF text has a line
+// per cell, and row reconstruction looks for matter numbers on their own line.
+func splitOCRRows(text string) string {
+	return ocrMatterNumberPattern.ReplaceAllString(text, "$1\n")
+}
+
+// recognizePage reads the text of a scanned page with OCR, joining the text
+// of each image on the page
+func recognizePage(ctx context.Context, ocr OCR, images []PageImage) (string, error) {
+	var texts []string
+	for _, image := range images {
+		text, err := ocr.Recognize(ctx, image)
+		if err != nil {
+			return "", err
+		}
+		texts = append(texts, splitOCRRows(text))
+	}
+	return strings.Join(texts, "\n"), nil
+}
@@ -0,0 +1,153 @@
+package wclist
+
+import (
+	"bytes"
+	"compress/zlib"
+	"context"
+	"errors"
+	"image"
+	"image/jpeg"
+	"image/png"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeOCR returns the same text for every image and records what it was given
+type fakeOCR struct {
+	text   string
+	mu     sync.Mutex
+	images []PageImage
+}
+
+func (o *fakeOCR) Recognize(ctx context.Context, image PageImage) (string, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.images = append(o.images, image)
+	return o.text, nil
+}
+
+// scannedPDF builds a cover page followed by image-only pages holding a
+// JPEG, a Flate-compressed greyscale bitmap and a CCITT fax image
+func scannedPDF(t *testing.T) []byte {
+	gray := image.NewGray(image.Rect(0, 0, 8, 8))
+	for i := range gray.Pix {
+		gray.Pix[i] = byte(i * 4)
+	}
+
+	var jpegData bytes.Buffer
+	if err := jpeg.Encode(&jpegData, gray, nil); err != nil {
+		t.Fatalf("Failed to encode JPEG: %v", err)
+	}
+
+	var flateData bytes.Buffer
+	zw := zlib.NewWriter(&flateData)
+	zw.Write(gray.Pix)
+	zw.Close()
+
+	drawImage := "q 612 0 0 792 0 0 cm /Im1 Do Q"
+	return buildTestPDF(nil,
+		[]string{textPage("WARDEN'S COURT KALGOORLIE"), drawImage, drawImage, drawImage},
+		map[int]testPDFImage{
+			1: {"/Width 8 /Height 8 /ColorSpace /DeviceGray /BitsPerComponent 8 /Filter /DCTDecode", jpegData.Bytes()},
+			2: {"/Width 8 /Height 8 /ColorSpace /DeviceGray /BitsPerComponent 8 /Filter /FlateDecode", flateData.Bytes()},
+			3: {"/Width 8 /Height 8 /ColorSpace /DeviceGray /BitsPerComponent 1 /Filter /CCITTFaxDecode /DecodeParms << /K -1 /Columns 8 /Rows 8 >>", []byte("fax data")},
+		})
+}
+
+func TestScannedPDF(t *testing.T) {
+	data := scannedPDF(t)
+
+	read := func(ocr OCR) (*CauseList, error) {
+		opts := DefaultReadOptions()
+		opts.OCR = ocr
+		cl := NewCauseList("Western Australia", "Kalgoorlie", time.Now())
+		err := cl.ReadCauseListOptions(context.Background(), bytes.NewReader(data), int64(len(data)), opts)
+		return cl, err
+	}
+
+	t.Run("OCR", func(t *testing.T) {
+		ocr := &fakeOCR{text: "OBJECTIONS\n1 698561 KARORA (HIGGINSVILLE) PTY LTD E 15/2082 FMG RESOURCES PTY LTD\n"}
+		cl, err := read(ocr)
+		if err != nil {
+			t.Fatalf("Failed to read scanned PDF: %v", err)
+		}
+
+		if !reflect.DeepEqual(cl.Report.OCRPages, []int{2, 3, 4}) {
+			t.Fatalf("Expected pages 2 to 4 to be read by OCR, got %v", cl.Report.OCRPages)
+		}
+		if len(cl.Items) != 3 {
+			t.Fatalf("Expected an item from each scanned page, got %d", len(cl.Items))
+		}
+		for _, item := range cl.Items {
+			objection, ok := item.(ObjectionItems)
+			if !ok || objection.ObjectionNumber != 698561 || objection.TenementNumber != "E 15/2082" {
+				t.Fatalf("Unexpected item from OCR text: %+v", item)
+			}
+			if !objection.IsLowConfidence() {
+				t.Fatalf("Expected OCR items to be flagged as low confidence")
+			}
+		}
+
+		formats := map[string]PageImage{}
+		for _, image := range ocr.images {
+			formats[image.Format] = image
+		}
+		if len(formats) != 3 {
+			t.Fatalf("Expected jpeg, png and tiff images, got %v", formats)
+		}
+		if _, err := jpeg.Decode(bytes.NewReader(formats["jpeg"].Data)); err != nil {
+			t.Fatalf("Expected the JPEG to be passed on unchanged: %v", err)
+		}
+		decoded, err := png.Decode(bytes.NewReader(formats["png"].Data))
+		if err != nil {
+			t.Fatalf("Failed to decode PNG: %v", err)
+		}
+		if got := decoded.(*image.Gray).GrayAt(3, 1).Y; got != 44 {
+			t.Fatalf("Expected pixel (3, 1) to be 44, got %d", got)
+		}
+		if tiff := formats["tiff"].Data; !bytes.HasPrefix(tiff, []byte("II*\x00")) || !bytes.HasSuffix(tiff, []byte("fax data")) {
+			t.Fatalf("Expected the fax data wrapped in a TIFF file")
+		}
+	})
+
+	t.Run("no OCR", func(t *testing.T) {
+		cl, err := read(nil)
+		if !errors.Is(err, ErrNoItemsFound) {
+			t.Fatalf("Expected ErrNoItemsFound, got %v", err)
+		}
+		if len(cl.Report.PageErrors) != 3 || !errors.Is(cl.Report.PageErrors[0], ErrScannedPage) {
+			t.Fatalf("Expected each scanned page to be reported, got %v", cl.Report.PageErrors)
+		}
+	})
+
+	t.Run("tesseract missing", func(t *testing.T) {
+		cl, _ := read(TesseractOCR{Path: "wclist-missing-tesseract"})
+		if len(cl.Report.PageErrors) != 3 || !errors.Is(cl.Report.PageErrors[0], ErrScannedPage) {
+			t.Fatalf("Expected each scanned page to be reported, got %v", cl.Report.PageErrors)
+		}
+	})
+
+	t.Run("oversized images", func(t *testing.T) {
+		drawImage := "q 612 0 0 792 0 0 cm /Im1 Do Q"
+		data := buildTestPDF(nil,
+			[]string{textPage("WARDEN'S COURT KALGOORLIE"), drawImage, drawImage, drawImage},
+			map[int]testPDFImage{
+				1: {"/Width 60000 /Height 60000 /ColorSpace /DeviceRGB /BitsPerComponent 8", []byte("tiny")},
+				2: {"/Width 4000000000 /Height 1 /ColorSpace /DeviceGray /BitsPerComponent 8 /Filter /FlateDecode", []byte("tiny")},
+				3: {"/Width 8 /Height 8 /ColorSpace /DeviceGray /BitsPerComponent 8 /Filter /DCTDecode", bytes.Repeat([]byte{0xff}, 2048)},
+			})
+		ocr := &fakeOCR{}
+		opts := DefaultReadOptions()
+		opts.OCR = ocr
+		cl := NewCauseList("Western Australia", "Kalgoorlie", time.Now())
+		cl.ReadCauseListOptions(context.Background(), bytes.NewReader(data), int64(len(data)), opts)
+		if len(cl.Report.PageErrors) != 2 || !errors.Is(cl.Report.PageErrors[0], ErrScannedPage) {
+			t.Fatalf("Expected the images larger than their data to be rejected, got %v", cl.Report.PageErrors)
+		}
+		if len(ocr.images) != 1 || len(ocr.images[0].Data) != 2048 {
+			t.Fatalf("Expected only the JPEG to be read, got %d images", len(ocr.images))
+		}
+	})
+}
@@ -0,0 +1,64 @@
+package wclist
+
+import (
+	"runtime"
+	"time"
+)
+
+// ReadOptions limits the resources used to read a cause list and supplies
+// the password for encrypted PDFs. A zero value for any limit disables it.
+type ReadOptions struct {
+	MaxBytes    int64         // Largest document accepted
+	MaxPages    int           // Most pages accepted in a PDF
+	PageTimeout time.Duration // Longest time spent parsing one PDF page
+	Workers     int           // Pages parsed at once; defaults to GOMAXPROCS
+
+	// Password opens encrypted PDFs that have a user password. PDFs
+	// restricted only by an owner password open without one.
+	Password string
+
+	// OCR reads the text of scanned, image-only PDF pages. Without it,
+	// scanned pages are skipped with ErrScannedPage.
+	OCR OCR
+
+	// Profile describes the layout of the cause list. When nil, the
+	// registered profile that best matches the document is used.
+	Profile *Profile
+
+	// Strict rejects rows whose items score below MinConfidence when
+	// validated, recording them in the report as row errors instead
+	Strict        bool
+	MinConfidence float64 // Defaults to DefaultMinConfidence
+
+	// Cache holds the results of earlier reads by CacheKey. Documents read
+	// before with the same options are returned from it without parsing.
+	Cache ParseCache
+}
+
+// DefaultReadOptions returns limits suitable for documents from untrusted sources
+func DefaultReadOptions() ReadOptions {
+	return ReadOptions{
+		MaxBytes:    50 << 20,
+		MaxPages:    500,
+		PageTimeout: 10 * time.Second,
+		OCR:         TesseractOCR{},
+	}
+}
+
+// unlimitedReadOptions returns the options used by ReadCauseList and
+// StreamItems, which read trusted documents without limits
+func unlimitedReadOptions() ReadOptions {
+	return ReadOptions{OCR: TesseractOCR{}}
+}
+
+// workers returns the size of the worker pool for the given number of pages
+func (o ReadOptions) workers(pages int) int {
+	workers := o.Workers
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	if workers > pages {
+		workers = pages
+	}
+	return workers
+}
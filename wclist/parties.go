@@ -0,0 +1,215 @@
+package wclist
+
+import (
+	"regexp"
+	"strings"
+)
+
+// PartyKind is whether a party is a company, an individual or a government body
+type PartyKind string
+
+const (
+	PartyCompany    PartyKind = "company"
+	PartyIndividual PartyKind = "individual"
+	PartyGovernment PartyKind = "government"
+)
+
+// PartyRole is the part a party plays in a matter
+type PartyRole string
+
+const (
+	RoleApplicant  PartyRole = "applicant"
+	RoleObjector   PartyRole = "objector"
+	RoleRespondent PartyRole = "respondent"
+	RolePlaintiff  PartyRole = "plaintiff"
+	RoleDefendant  PartyRole = "defendant"
+)
+
+// Applying reports whether the role is on the applying side of a matter.
+// Objectors, respondents and defendants are on the responding side.
+func (r PartyRole) Applying() bool {
+	return r == RoleApplicant || r == RolePlaintiff
+}
+
+// Party is one of the parties named in a field of a cause list item
+type Party struct {
+	Name   string    `json:"name"`
+	Kind   PartyKind `json:"kind"`
+	ACN    string    `json:"acn,omitempty"`     // Australian Company Number, digits only
+	CareOf string    `json:"care_of,omitempty"` // Agent the party is listed care of
+	Role   PartyRole `json:"role"`
+}
+
+var (
+	// careOfPattern matches the start of an agent, e.g. "c/- Agent Services"
+	careOfPattern = regexp.MustCompile(`(?i)\s*\b(?:c/-|c/o|care of)\s*`)
+	// acnPattern matches an ACN, with or without brackets and spaces
+	acnPattern = regexp.MustCompile(`(?i)\(?\s*\bA\.?C\.?N\.?\s*:?\s*(\d{3})\s*(\d{3})\s*(\d{3})\s*\)?`)
+	// othersPattern matches unnamed parties at the end of a field, e.g. "AND OTHERS"
+	othersPattern = regexp.MustCompile(`(?i)\s*(?:\band\b|&)\s*(?:others|ors)\.?\s*$`)
+	// partySeparatorPattern matches the words joining two parties
+	partySeparatorPattern = regexp.MustCompile(`(?i)\s*(?:&|\band\b)\s*`)
+	// companySuffixPattern matches the end of a company name, and any ACN,
+	// after which a comma separates parties rather than a surname from given
+	// names
+	companySuffixPattern = regexp.MustCompile(`(?i)\b(?:pty\.?|ltd\.?|limited|n\.?l\.?|inc\.?|corporation|corp\.?|plc|llc|trust|association)\)?(?:\s*\(?\s*a\.?c\.?n\.?[\s:]*[\d\s]+\)?)?$`)
+	// surnamePattern matches an upper case surname followed by a comma
+	surnamePattern = regexp.MustCompile(`^\s*[A-Z][A-Z'\-]+(?:\s+[A-Z][A-Z'\-]+)*,`)
+	// companyPattern matches words only found in company names
+	companyPattern = regexp.MustCompile(`(?i)\b(?:pty|ltd|limited|n\.?l|inc|corporation|corp|company|co|plc|llc|trust|association|holdings|group)\b`)
+	// governmentPattern matches words only found in the names of government bodies
+	governmentPattern = regexp.MustCompile(`(?i)\b(?:minister|state of western australia|department|commonwealth|shire|city of|town of|council|crown|attorney general|authority|commissioner|director general)\b`)
+)
+
+// ParseParties splits a party field such as "ABC PTY LTD & XYZ PTY LTD",
+// "JOHN SMITH AND OTHERS" or "DEF NL c/- Agent Services" into its parties,
+// each given the same role. An agent named after "c/-" is the agent of every
+// party in the field, and unnamed "others" are dropped.
+func ParseParties(text string, role PartyRole) []Party {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return nil
+	}
+
+	var careOf string
+	if loc := careOfPattern.FindStringIndex(text); loc != nil {
+		careOf = strings.TrimSpace(text[loc[1]:])
+		text = text[:loc[0]]
+	}
+	text = othersPattern.ReplaceAllString(text, "")
+
+	var parties []Party
+	for _, name := range splitPartyNames(text) {
+		party := Party{Role: role, CareOf: careOf}
+		if match := acnPattern.FindStringSubmatch(name); match != nil {
+			party.ACN = match[1] + match[2] + match[3]
+			name = acnPattern.ReplaceAllString(name, " ")
+		}
+		party.Name = whitespacePattern.ReplaceAllString(strings.Trim(name, " ,"), " ")
+		if party.Name == "" {
+			continue
+		}
+		party.Kind = partyKind(party)
+		parties = append(parties, party)
+	}
+	return parties
+}
+
+// splitPartyNames splits text at ";", at commas between parties, and at "&"
+// and "and" unless they join the words of one name, e.g. "SMITH & SONS PTY LTD"
+func splitPartyNames(text string) []string {
+	var names []string
+	for _, part := range strings.Split(text, ";") {
+		for _, name := range splitJoinedNames(part) {
+			for {
+				comma := indexPartyComma(name)
+				if comma < 0 {
+					break
+				}
+				names = append(names, name[:comma])
+				name = name[comma+1:]
+			}
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// splitJoinedNames splits text at "&" and "and". The words before one are
+// kept with the words after it when they name a government body, such as
+// "MINISTER FOR MINES AND PETROLEUM", or when they don't end a company name
+// and the words after it do belong to one.
+func splitJoinedNames(text string) []string {
+	var names []string
+	start := 0
+	for _, loc := range partySeparatorPattern.FindAllStringIndex(text, -1) {
+		before, after := strings.TrimSpace(text[start:loc[0]]), text[loc[1]:]
+		if next := partySeparatorPattern.FindStringIndex(after); next != nil {
+			after = after[:next[0]]
+		}
+		if governmentPattern.MatchString(before) ||
+			!companySuffixPattern.MatchString(before) && companyPattern.MatchString(after) {
+			continue
+		}
+		names = append(names, text[start:loc[0]])
+		start = loc[1]
+	}
+	return append(names, text[start:])
+}
+
+// indexPartyComma returns the position of the first comma in name that
+// separates two parties, or -1. A comma separates parties when it follows a
+// company name, or when it follows an individual listed as "SURNAME, Given"
+// and is followed by another surname, e.g. "WATTS, Glenn, WATTS, David".
+func indexPartyComma(name string) int {
+	for i, r := range name {
+		if r != ',' {
+			continue
+		}
+		before := strings.TrimSpace(name[:i])
+		if companySuffixPattern.MatchString(before) ||
+			strings.Contains(before, ",") && surnamePattern.MatchString(name[i+1:]) {
+			return i
+		}
+	}
+	return -1
+}
+
+// partyKind guesses the kind of a party from its name
+func partyKind(party Party) PartyKind {
+	switch {
+	case governmentPattern.MatchString(party.Name):
+		return PartyGovernment
+	case party.ACN != "" || companyPattern.MatchString(party.Name):
+		return PartyCompany
+	}
+	return PartyIndividual
+}
+
+// ItemParties returns the parties named in an item. Items of types without
+// a GetParties method have their applying and responding parties split with
+// the applicant and respondent roles.
+func ItemParties(item CauseListItem) []Party {
+	if v, ok := item.(interface{ GetParties() []Party }); ok {
+		return v.GetParties()
+	}
+	return append(ParseParties(item.GetApplyingParty(), RoleApplicant), ParseParties(item.GetRespondingParty(), RoleRespondent)...)
+}
+
+// withParties returns a copy of the item with its parties parsed, so that
+// they aren't parsed again each time they are needed
+func withParties(item CauseListItem) CauseListItem {
+	v, ok := item.(interface{ GetParties() []Party })
+	if !ok {
+		return item
+	}
+	parties := v.GetParties()
+	return updateCLIItems(item, func(c *CLIItems) { c.parties = &parties })
+}
+
+// partyNames returns the normalised names of the parties on the applying
+// and responding sides of an item
+func partyNames(item CauseListItem) (applying, responding []string) {
+	for _, party := range ItemParties(item) {
+		name := normalizeName(party.Name)
+		if name == "" {
+			continue
+		}
+		if party.Role.Applying() {
+			applying = append(applying, name)
+		} else {
+			responding = append(responding, name)
+		}
+	}
+	return applying, responding
+}
+
+// anyNameMatches reports whether name matches any of the normalised party names
+func anyNameMatches(name string, parties []string) bool {
+	for _, party := range parties {
+		if normalizedNamesMatch(name, party) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,75 @@
+package wclist
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParseParties(t *testing.T) {
+	tests := []struct {
+		text string
+		want []Party
+	}{
+		{"", nil},
+		{"ABC PTY LTD & XYZ PTY LTD", []Party{
+			{Name: "ABC PTY LTD", Kind: PartyCompany, Role: RoleObjector},
+			{Name: "XYZ PTY LTD", Kind: PartyCompany, Role: RoleObjector},
+		}},
+		{"JOHN SMITH AND OTHERS", []Party{
+			{Name: "JOHN SMITH", Kind: PartyIndividual, Role: RoleObjector},
+		}},
+		{"DEF NL c/- Agent Services", []Party{
+			{Name: "DEF NL", Kind: PartyCompany, CareOf: "Agent Services", Role: RoleObjector},
+		}},
+		{"LAMERTON PTY LTD ACN 123 456 789, GEODA PTY LTD", []Party{
+			{Name: "LAMERTON PTY LTD", Kind: PartyCompany, ACN: "123456789", Role: RoleObjector},
+			{Name: "GEODA PTY LTD", Kind: PartyCompany, Role: RoleObjector},
+		}},
+		{"MCCLAREN, Kym Anthony and SMITH, Jane", []Party{
+			{Name: "MCCLAREN, Kym Anthony", Kind: PartyIndividual, Role: RoleObjector},
+			{Name: "SMITH, Jane", Kind: PartyIndividual, Role: RoleObjector},
+		}},
+		{"STEHN, Anthony Paterson, BROWN, Michael John Barry", []Party{
+			{Name: "STEHN, Anthony Paterson", Kind: PartyIndividual, Role: RoleObjector},
+			{Name: "BROWN, Michael John Barry", Kind: PartyIndividual, Role: RoleObjector},
+		}},
+		{"D. & C. GERAGHTY PTY LTD", []Party{
+			{Name: "D. & C. GERAGHTY PTY LTD", Kind: PartyCompany, Role: RoleObjector},
+		}},
+		{"SMITH & SONS MINING PTY LTD c/- Hetherington Exploration & Mining Title Services", []Party{
+			{Name: "SMITH & SONS MINING PTY LTD", Kind: PartyCompany, CareOf: "Hetherington Exploration & Mining Title Services", Role: RoleObjector},
+		}},
+		{"MINISTER FOR MINES AND PETROLEUM", []Party{
+			{Name: "MINISTER FOR MINES AND PETROLEUM", Kind: PartyGovernment, Role: RoleObjector},
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			if got := ParseParties(tt.text, RoleObjector); !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("Expected %+v, got %+v", tt.want, got)
+			}
+		})
+	}
+
+	t.Run("Matching", func(t *testing.T) {
+		cl := NewCauseList("Western Australia", "Kalgoorlie", time.Now())
+		cl.Items = append(cl.Items, ObjectionItems{
+			CLIItems:      CLIItems{MatterNumber: 1, TenementNumber: "E 15/2082"},
+			ObjectorName:  "ABC PTY LTD & XYZ PTY LTD c/- Smith Tenement Services",
+			ApplicantName: "KARORA (HIGGINSVILLE) PTY LTD",
+		})
+
+		matches := cl.SearchAssignedMatters([]AssignedMatter{{ClientName: "XYZ Pty Ltd"}})
+		if len(matches) != 1 || matches[0].MatchReason != reasonClientResponding {
+			t.Fatalf("Expected the second objector to be matched, got %+v", matches)
+		}
+		if matches := cl.SearchAssignedMatters([]AssignedMatter{{ClientName: "Smith Tenement Services"}}); len(matches) != 0 {
+			t.Fatalf("Expected the agent not to be matched as a party, got %+v", matches)
+		}
+		if got := cl.searchLinear([]AssignedMatter{{ClientName: "XYZ Pty Ltd"}}); !reflect.DeepEqual(got, matches) {
+			t.Fatalf("Expected the linear search to match the index, got %+v", got)
+		}
+	})
+}
@@ -0,0 +1,402 @@
+package wclist
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ledongthuc/pdf"
+)
+
+// pdfSource reads cause lists published as PDF files
+type pdfSource struct{}
+
+func (pdfSource) Name() string { return "pdf" }
+
+func (pdfSource) Detect(r io.ReaderAt, size int64) bool {
+	return sniffContentType(r, size) == "application/pdf"
+}
+
+// Read parses the pages of the PDF in a bounded pool of workers. Pages that
+// fail are recorded in the report and skipped.
+func (pdfSource) Read(ctx context.Context, cl *CauseList, r io.ReaderAt, size int64, opts ReadOptions, report *ParseReport) ([]CauseListItem, error) {
+	doc, err := openPDF(ctx, r, size, opts)
+	if err != nil {
+		return nil, err
+	}
+	numPages := doc.numPages
+	report.Pages = numPages
+	report.Profile = doc.profile.Name
+	report.Sitting = coverSitting(doc.cover)
+	report.Released = doc.released
+
+	// Skip the cover pages and process the rest
+	firstPage := doc.profile.firstPage()
+	if numPages < firstPage {
+		return nil, nil
+	}
+
+	// Each page writes only to its own slot, which keeps the output order fixed
+	results := make([]pdfPage, numPages+1)
+	pages := make(chan int)
+
+	var wg sync.WaitGroup
+	for w := 0; w < opts.workers(numPages-firstPage+1); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range pages {
+				results[i] = cl.parsePDFPageSafely(ctx, doc, i)
+			}
+		}()
+	}
+
+	go func() {
+		defer close(pages)
+		for i := firstPage; i <= numPages; i++ {
+			select {
+			case pages <- i:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	var items []CauseListItem
+	for i := firstPage; i <= numPages; i++ {
+		if results[i].err != nil {
+			report.addPageError(i, results[i].err)
+			continue
+		}
+		items = append(items, results[i].items...)
+		report.RowErrors = append(report.RowErrors, results[i].report.RowErrors...)
+		report.OCRPages = append(report.OCRPages, results[i].report.OCRPages...)
+		report.UnboxedPages = append(report.UnboxedPages, results[i].report.UnboxedPages...)
+	}
+	return items, nil
+}
+
+// Stream yields the items of each PDF page in order, parsing the next page
+// only once the items of the previous one have been consumed. Pages that
+// fail are yielded as a PageError and the following pages are still read,
+// until the context is cancelled.
+func (pdfSource) Stream(ctx context.Context, cl *CauseList, r io.ReaderAt, size int64, opts ReadOptions) iter.Seq2[CauseListItem, error] {
+	return func(yield func(CauseListItem, error) bool) {
+		doc, err := openPDF(ctx, r, size, opts)
+		if err != nil {
+			yield(nil, err)
+			return
+		}
+
+		for i := doc.profile.firstPage(); i <= doc.numPages; i++ {
+			page := cl.parsePDFPageSafely(ctx, doc, i)
+			if err := ctx.Err(); err != nil {
+				yield(nil, err)
+				return
+			}
+			if page.err != nil {
+				if !yield(nil, PageError{Page: i, Err: page.err}) {
+					return
+				}
+				continue
+			}
+			for _, item := range page.items {
+				if !yield(item, nil) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// encryptedPDF reports whether a PDF is encrypted, reading its trailer
+// until the context is cancelled. It gives an error if the trailer can't be
+// read.
+func encryptedPDF(ctx context.Context, r io.ReaderAt, size int64) (bool, error) {
+	objects, err := newPDFObjects(ctx, r, size)
+	if err != nil {
+		return false, err
+	}
+	_, ok := dictValue(objects.trailer, "Encrypt")
+	return ok, nil
+}
+
+// pdfDocument is an open PDF along with the options and profile it is read with
+type pdfDocument struct {
+	reader   *pdf.Reader
+	file     io.ReaderAt
+	size     int64
+	numPages int
+	opts     ReadOptions
+	profile  *Profile
+	cover    string    // Text of the first page
+	released time.Time // When the PDF was last modified, zero if unknown
+
+	// objects reads the file's own objects, checked before the PDF reader
+	// opened it, and pages finds the images the PDF reader can't return
+	// undecoded. Pages are only read for scanned pages.
+	objects *pdfObjects
+	pages   func() ([]pageNode, error)
+}
+
+// openPDF opens a PDF, checks its page count against the limits and chooses
+// the profile, detecting it from the first page unless opts names one. Errors
+// and panics from the PDF reader are reported as ErrMalformedPDF, except for
+// encrypted PDFs that can't be opened with opts.Password, which give
+// ErrEncryptedPDF. PDFs the PDF reader would never finish reading are
+// refused first, see checkedPDFObjects, and the first page is read within
+// the page timeout, like the rest.
+func openPDF(ctx context.Context, r io.ReaderAt, size int64, opts ReadOptions) (doc *pdfDocument, err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			doc, err = nil, fmt.Errorf("%w: %v", ErrMalformedPDF, p)
+		}
+	}()
+
+	objects, err := checkedPDFObjects(ctx, r, size)
+	if err != nil {
+		return nil, err
+	}
+	pdfReader, err := pdf.NewReaderEncrypted(r, size, pdfPassword(opts.Password))
+	switch {
+	case errors.Is(err, pdf.ErrInvalidPassword) && opts.Password != "":
+		return nil, ErrWrongPassword
+	case errors.Is(err, pdf.ErrInvalidPassword):
+		return nil, fmt.Errorf("%w: a password is required", ErrEncryptedPDF)
+	case unsupportedEncryption(err):
+		return nil, fmt.Errorf("%w: %v", ErrEncryptedPDF, err)
+	case err != nil:
+		return nil, fmt.Errorf("%w: %w", ErrMalformedPDF, err)
+	}
+
+	numPages := pdfReader.NumPage()
+	if opts.MaxPages > 0 && numPages > opts.MaxPages {
+		return nil, fmt.Errorf("%w: %d pages, limit is %d", ErrTooManyPages, numPages, opts.MaxPages)
+	}
+
+	// A cover that can't be read only leaves the profile to be detected
+	// without it; the first page reports its own error if it has rows
+	var cover string
+	if numPages > 0 {
+		cover, _ = boundedPage(ctx, opts.PageTimeout, func(context.Context) (string, error) {
+			return pdfReader.Page(1).GetPlainText(nil)
+		})
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+	}
+	profile, err := opts.profile(func() string { return cover })
+	if err != nil {
+		return nil, err
+	}
+
+	doc = &pdfDocument{reader: pdfReader, file: r, size: size, numPages: numPages, opts: opts, profile: profile, cover: cover, objects: objects}
+	info := pdfReader.Trailer().Key("Info")
+	for _, key := range []string{"ModDate", "CreationDate"} {
+		if date, ok := parsePDFDate(info.Key(key).Text()); ok {
+			doc.released = date
+			break
+		}
+	}
+	doc.pages = sync.OnceValues(func() ([]pageNode, error) {
+		_, pages, err := objects.pages()
+		return pages, err
+	})
+	return doc, nil
+}
+
+// pdfDatePattern matches the dates of PDF document information, e.g.
+// "D:20250528155116+08'00'". Everything after the year is optional.
+var pdfDatePattern = regexp.MustCompile(`^(?:D:)?(\d{4})(\d{2})?(\d{2})?(\d{2})?(\d{2})?(\d{2})?(?:([Zz+-])(\d{2})?'?(\d{2})?'?)?$`)
+
+// parsePDFDate parses a date of PDF document information. Dates without a
+// time zone are taken to be UTC.
+func parsePDFDate(text string) (time.Time, bool) {
+	m := pdfDatePattern.FindStringSubmatch(strings.TrimSpace(text))
+	if m == nil {
+		return time.Time{}, false
+	}
+	field := func(i, unset int) int {
+		if m[i] == "" {
+			return unset
+		}
+		n, _ := strconv.Atoi(m[i])
+		return n
+	}
+	loc := time.UTC
+	if m[7] == "+" || m[7] == "-" {
+		offset := field(8, 0)*3600 + field(9, 0)*60
+		if m[7] == "-" {
+			offset = -offset
+		}
+		loc = time.FixedZone("", offset)
+	}
+	month, day, hour, minute, second := field(2, 1), field(3, 1), field(4, 0), field(5, 0), field(6, 0)
+	if month < 1 || month > 12 || day < 1 || day > 31 || hour > 23 || minute > 59 || second > 59 {
+		return time.Time{}, false
+	}
+	return time.Date(field(1, 0), time.Month(month), day, hour, minute, second, 0, loc), true
+}
+
+// unsupportedEncryption reports whether the PDF reader rejected a document
+// for its encryption scheme. The reader has no error value for this, so the
+// message is matched as written by github.com/ledongthuc/pdf
+// v0.0.0-20250511090121-5959a4027728 (Reader.initEncrypt).
+func unsupportedEncryption(err error) bool {
+	return err != nil && strings.HasPrefix(err.Error(), "unsupported PDF: encryption")
+}
+
+// pdfPassword supplies the password to the PDF reader, which asks for
+// passwords until one works or it is given an empty one
+func pdfPassword(password string) func() string {
+	if password == "" {
+		return nil
+	}
+	tried := false
+	return func() string {
+		if tried {
+			return ""
+		}
+		tried = true
+		return password
+	}
+}
+
+// parsePDFPageSafely parses a page through boundedPage, so that a page that
+// panics or takes too long only fails that page
+func (cl *CauseList) parsePDFPageSafely(ctx context.Context, doc *pdfDocument, i int) pdfPage {
+	page, err := boundedPage(ctx, doc.opts.PageTimeout, func(ctx context.Context) (pdfPage, error) {
+		var page pdfPage
+		page.items, page.err = cl.parsePDFPage(ctx, doc, i, &page.report)
+		return page, nil
+	})
+	if err != nil {
+		return pdfPage{err: err}
+	}
+	return page
+}
+
+// boundedPage reads a page in its own goroutine, turning panics into
+// ErrPagePanic and giving up with ErrPageTimeout after the timeout, if there
+// is one, or when the context is cancelled. The PDF reader can't be
+// interrupted, so a page that times out keeps its goroutine busy until the
+// reader returns; its result is discarded. The context given to read, which
+// stops OCR, is cancelled when the page times out.
+func boundedPage[T any](ctx context.Context, timeout time.Duration, read func(context.Context) (T, error)) (T, error) {
+	pageCtx, cancel := context.WithCancel(ctx)
+	if timeout > 0 {
+		pageCtx, cancel = context.WithTimeout(ctx, timeout)
+	}
+	defer cancel()
+
+	type result struct {
+		value T
+		err   error
+	}
+	done := make(chan result, 1)
+
+	go func() {
+		defer func() {
+			if p := recover(); p != nil {
+				done <- result{err: fmt.Errorf("%w: %v", ErrPagePanic, p)}
+			}
+		}()
+		value, err := read(pageCtx)
+		done <- result{value, err}
+	}()
+
+	var expired <-chan time.Time
+	if timeout > 0 {
+		timer := time.NewTimer(timeout)
+		defer timer.Stop()
+		expired = timer.C
+	}
+
+	var zero T
+	select {
+	case r := <-done:
+		return r.value, r.err
+	case <-expired:
+		return zero, fmt.Errorf("%w: gave up after %s", ErrPageTimeout, timeout)
+	case <-ctx.Done():
+		return zero, ctx.Err()
+	}
+}
+
+// pdfPage is the result of parsing one page of a PDF. The report holds the
+// page's row errors until they are gathered in page order.
+type pdfPage struct {
+	items  []CauseListItem
+	report ParseReport
+	err    error
+}
+
+// parsePDFPage extracts the items from one page of a PDF. Pages with images
+// but no text are scanned pages, which are read with OCR; their items are
+// flagged as low confidence. Items from pages with text record the area of
+// their row on the page. In strict mode, items with a low confidence are
+// rejected.
+func (cl *CauseList) parsePDFPage(ctx context.Context, doc *pdfDocument, i int, report *ParseReport) ([]CauseListItem, error) {
+	page := doc.reader.Page(i)
+	if page.V.IsNull() {
+		return nil, nil
+	}
+
+	// Extract text content from the page
+	content, err := page.GetPlainText(nil)
+	if err != nil {
+		return nil, fmt.Errorf("getting text: %w", err)
+	}
+
+	scanned := false
+	if strings.TrimSpace(content) == "" {
+		if !hasImages(page) {
+			return nil, nil
+		}
+		if doc.opts.OCR == nil {
+			return nil, fmt.Errorf("%w: no OCR configured", ErrScannedPage)
+		}
+		images, err := pageImages(doc, page, i)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrScannedPage, err)
+		}
+		if content, err = recognizePage(ctx, doc.opts.OCR, images); err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrScannedPage, err)
+		}
+		scanned = true
+		report.OCRPages = append(report.OCRPages, i)
+	}
+
+	// Parse the page content and extract items
+	items := cl.parsePageText(content, i, doc.profile, report)
+	if scanned {
+		for j, item := range items {
+			items[j] = withLowConfidence(item)
+		}
+	} else {
+		items = withRowBoxes(page, i, content, items, report)
+	}
+	return doc.opts.strictItems(items, report), nil
+}
@@ -0,0 +1,365 @@
+package wclist
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5"
+	"crypto/rc4"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ledongthuc/pdf"
+)
+
+// minimalPDF builds a PDF with one page per content stream
+func minimalPDF(contents ...string) []byte {
+	return buildTestPDF(nil, contents, nil)
+}
+
+// textPage returns a content stream showing each line as its own text object,
+// which the PDF reader separates with newlines
+func textPage(lines ...string) string {
+	var content strings.Builder
+	for i, line := range lines {
+		fmt.Fprintf(&content, "BT /F1 12 Tf 72 %d Td (%s) Tj ET\n", 720-14*i, line)
+	}
+	return content.String()
+}
+
+// testPDFImage is an image XObject, drawn on its page as /Im1
+type testPDFImage struct {
+	dict string // Dictionary entries other than the type and length
+	data []byte
+}
+
+// buildTestPDF builds a PDF with one page per content stream, encrypting the
+// streams when enc is set. Images are added to the pages with the same index.
+func buildTestPDF(enc *testPDFEncryption, contents []string, images map[int]testPDFImage) []byte {
+	// Each page has a page object, a content stream and an optional image
+	pageObject := func(i int) int { return 4 + 3*i }
+
+	var objects []string
+	kids := ""
+	for i := range contents {
+		kids += fmt.Sprintf("%d 0 R ", pageObject(i))
+	}
+	objects = append(objects,
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", kids, len(contents)),
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica >>",
+	)
+	for i, content := range contents {
+		stream := []byte(content)
+		if enc != nil {
+			stream = enc.encrypt(pageObject(i)+1, stream)
+		}
+
+		xobjects, imageObject := "", "null"
+		if image, ok := images[i]; ok {
+			xobjects = fmt.Sprintf("/XObject << /Im1 %d 0 R >>", pageObject(i)+2)
+			imageObject = fmt.Sprintf("<< /Type /XObject /Subtype /Image %s /Length %d >>\nstream\n%s\nendstream", image.dict, len(image.data), image.data)
+		}
+
+		objects = append(objects,
+			fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 612 792] /Resources << /Font << /F1 3 0 R >> %s >> /Contents %d 0 R >>", xobjects, pageObject(i)+1),
+			fmt.Sprintf("<< /Length %d >>\nstream\n%s\nendstream", len(stream), stream),
+			imageObject,
+		)
+	}
+
+	trailer := fmt.Sprintf("/Size %d /Root 1 0 R", len(objects)+1)
+	if enc != nil {
+		objects = append(objects, fmt.Sprintf("<< /Filter /Standard /V 2 /R 3 /Length 128 /O <%x> /U <%x> /P %d >>", enc.o, enc.u, testPDFPermissions))
+		trailer = fmt.Sprintf("/Size %d /Root 1 0 R /Encrypt %d 0 R /ID [<%x> <%x>]", len(objects)+1, len(objects), enc.id, enc.id)
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("%PDF-1.4\n")
+	offsets := make([]int, len(objects))
+	for i, object := range objects {
+		offsets[i] = buf.Len()
+		fmt.Fprintf(&buf, "%d 0 obj\n%s\nendobj\n", i+1, object)
+	}
+	xref := buf.Len()
+	fmt.Fprintf(&buf, "xref\n0 %d\n0000000000 65535 f \n", len(objects)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&buf, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&buf, "trailer\n<< %s >>\nstartxref\n%d\n%%%%EOF\n", trailer, xref)
+	return buf.Bytes()
+}
+
+// testPDFPermissions allows everything except printing and copying
+const testPDFPermissions = -4
+
+// testPDFPasswordPad pads passwords to 32 bytes (PDF 32000-1:2008, §7.6.3.3)
+var testPDFPasswordPad = []byte{
+	0x28, 0xBF, 0x4E, 0x5E, 0x4E, 0x75, 0x8A, 0x41, 0x64, 0x00, 0x4E, 0x56, 0xFF, 0xFA, 0x01, 0x08,
+	0x2E, 0x2E, 0x00, 0xB6, 0xD0, 0x68, 0x3E, 0x80, 0x2F, 0x0C, 0xA9, 0xFE, 0x64, 0x53, 0x69, 0x7A,
+}
+
+// testPDFEncryption encrypts a test PDF with the standard security handler
+// at revision 3, using 128-bit RC4 keys
+type testPDFEncryption struct {
+	key, o, u, id []byte
+}
+
+func newTestPDFEncryption(userPassword, ownerPassword string) *testPDFEncryption {
+	pad := func(password string) []byte {
+		return append([]byte(password), testPDFPasswordPad...)[:32]
+	}
+	// rc4 applies the revision 3 cipher, which repeats RC4 with the key
+	// XORed with each of 1 to 19
+	rc4 := func(key, data []byte) []byte {
+		out := append([]byte{}, data...)
+		for i := 0; i < 20; i++ {
+			roundKey := make([]byte, len(key))
+			for j := range key {
+				roundKey[j] = key[j] ^ byte(i)
+			}
+			c, _ := rc4.NewCipher(roundKey)
+			c.XORKeyStream(out, out)
+		}
+		return out
+	}
+	rehash := func(sum [16]byte) []byte {
+		for i := 0; i < 50; i++ {
+			sum = md5.Sum(sum[:])
+		}
+		return sum[:]
+	}
+	if ownerPassword == "" {
+		ownerPassword = userPassword
+	}
+
+	enc := &testPDFEncryption{id: []byte("wclist-test-pdf!")}
+	enc.o = rc4(rehash(md5.Sum(pad(ownerPassword))), pad(userPassword))
+
+	permissions := int32(testPDFPermissions)
+	p := uint32(permissions)
+	var keyInput []byte
+	keyInput = append(keyInput, pad(userPassword)...)
+	keyInput = append(keyInput, enc.o...)
+	keyInput = append(keyInput, byte(p), byte(p>>8), byte(p>>16), byte(p>>24))
+	keyInput = append(keyInput, enc.id...)
+	enc.key = rehash(md5.Sum(keyInput))
+
+	// Only the first 16 bytes of U are checked; the rest is padding
+	check := md5.Sum(append(append([]byte{}, testPDFPasswordPad...), enc.id...))
+	enc.u = append(rc4(enc.key, check[:]), make([]byte, 16)...)
+	return enc
+}
+
+// encrypt encrypts the data of an object with its own key
+func (enc *testPDFEncryption) encrypt(object int, data []byte) []byte {
+	key := md5.Sum(append(append([]byte{}, enc.key...), byte(object), byte(object>>8), byte(object>>16), 0, 0))
+	c, _ := rc4.NewCipher(key[:])
+	out := make([]byte, len(data))
+	c.XORKeyStream(out, data)
+	return out
+}
+
+func TestReadLimits(t *testing.T) {
+	data, err := os.ReadFile("../test/test.pdf")
+	if err != nil {
+		t.Fatalf("Failed to read test file: %v", err)
+	}
+
+	read := func(data []byte, opts ReadOptions) (*CauseList, error) {
+		cl := NewCauseList("Western Australia", "Kalgoorlie", time.Now())
+		err := cl.ReadCauseListOptions(context.Background(), bytes.NewReader(data), int64(len(data)), opts)
+		return cl, err
+	}
+
+	t.Run("malformed PDF", func(t *testing.T) {
+		cl, err := read(data[:len(data)/2], DefaultReadOptions())
+		if !errors.Is(err, ErrMalformedPDF) {
+			t.Fatalf("Expected ErrMalformedPDF, got %v", err)
+		}
+		if len(cl.Items) != 0 {
+			t.Fatalf("Expected no items, got %d", len(cl.Items))
+		}
+	})
+
+	t.Run("too large", func(t *testing.T) {
+		opts := DefaultReadOptions()
+		opts.MaxBytes = 1024
+		if _, err := read(data, opts); !errors.Is(err, ErrTooLarge) {
+			t.Fatalf("Expected ErrTooLarge, got %v", err)
+		}
+
+		// Readers without random access are limited while reading
+		cl := NewCauseList("Western Australia", "Kalgoorlie", time.Now())
+		err := cl.ReadCauseListOptions(context.Background(), bytes.NewBuffer(data), -1, opts)
+		if !errors.Is(err, ErrTooLarge) {
+			t.Fatalf("Expected ErrTooLarge from a stream, got %v", err)
+		}
+	})
+
+	t.Run("too many pages", func(t *testing.T) {
+		opts := DefaultReadOptions()
+		opts.MaxPages = 5
+		_, err := read(data, opts)
+		if !errors.Is(err, ErrTooManyPages) || !errors.Is(err, ErrMalformedPDF) {
+			t.Fatalf("Expected ErrTooManyPages in the ErrMalformedPDF family, got %v", err)
+		}
+	})
+
+	t.Run("bad page is skipped", func(t *testing.T) {
+		pdf := minimalPDF(
+			"BT /F1 12 Tf 72 720 Td (WARDEN'S COURT) Tj ET",
+			"BT /F1 12 Tf 72 720 Td 1 2 Tj ET",
+			"BT /F1 12 Tf 72 720 Td (OBJECTIONS) Tj ET",
+		)
+		// The other pages hold no rows, but the report is still recorded
+		cl, err := read(pdf, DefaultReadOptions())
+		if !errors.Is(err, ErrNoItemsFound) {
+			t.Fatalf("Expected ErrNoItemsFound, got %v", err)
+		}
+		if cl.Report.Format != "pdf" || cl.Report.Pages != 3 {
+			t.Fatalf("Expected a 3 page pdf report, got %q with %d pages", cl.Report.Format, cl.Report.Pages)
+		}
+		if len(cl.Report.PageErrors) != 1 || cl.Report.PageErrors[0].Page != 2 {
+			t.Fatalf("Expected an error for page 2, got %v", cl.Report.PageErrors)
+		}
+
+		var pageErrors []int
+		for _, err := range cl.StreamItems(bytes.NewReader(pdf), int64(len(pdf))) {
+			var pageErr PageError
+			if errors.As(err, &pageErr) {
+				pageErrors = append(pageErrors, pageErr.Page)
+			}
+		}
+		if len(pageErrors) != 1 || pageErrors[0] != 2 {
+			t.Fatalf("Expected streaming to report page 2, got %v", pageErrors)
+		}
+	})
+
+	t.Run("bounded page reads", func(t *testing.T) {
+		if _, err := boundedPage(context.Background(), 0, func(context.Context) (string, error) { panic("bad cover") }); !errors.Is(err, ErrPagePanic) {
+			t.Fatalf("Expected ErrPagePanic, got %v", err)
+		}
+		release := make(chan struct{})
+		defer close(release)
+		if _, err := boundedPage(context.Background(), 10*time.Millisecond, func(context.Context) (string, error) {
+			<-release
+			return "", nil
+		}); !errors.Is(err, ErrPageTimeout) {
+			t.Fatalf("Expected ErrPageTimeout, got %v", err)
+		}
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		if _, err := boundedPage(ctx, 0, func(context.Context) (string, error) {
+			<-release
+			return "", nil
+		}); !errors.Is(err, context.Canceled) {
+			t.Fatalf("Expected context.Canceled, got %v", err)
+		}
+	})
+
+	t.Run("error family", func(t *testing.T) {
+		for _, err := range []error{ErrTooManyPages, ErrPageTimeout, ErrPagePanic} {
+			wrapped := fmt.Errorf("page 3: %w", err)
+			if !errors.Is(wrapped, ErrMalformedPDF) || !errors.Is(wrapped, err) {
+				t.Fatalf("Expected %v to match itself and ErrMalformedPDF", err)
+			}
+		}
+		if errors.Is(ErrTooLarge, ErrMalformedPDF) {
+			t.Fatalf("ErrTooLarge shouldn't be a malformed PDF error")
+		}
+	})
+
+	t.Run("page error without a cause", func(t *testing.T) {
+		data, err := json.Marshal(PageError{Page: 4})
+		if err != nil {
+			t.Fatalf("Failed to marshal page error: %v", err)
+		}
+		if string(data) != `{"error":"","page":4}` {
+			t.Fatalf("Unexpected page error JSON: %s", data)
+		}
+	})
+
+	t.Run("page error round trip", func(t *testing.T) {
+		data, err := json.Marshal(PageError{Page: 4, Err: ErrPageTimeout})
+		if err != nil {
+			t.Fatalf("Failed to marshal page error: %v", err)
+		}
+		var decoded PageError
+		if err := json.Unmarshal(data, &decoded); err != nil {
+			t.Fatalf("Failed to unmarshal page error: %v", err)
+		}
+		if decoded.Page != 4 || decoded.Err == nil || decoded.Err.Error() != ErrPageTimeout.Error() {
+			t.Fatalf("Expected page 4 with the timeout's message, got %+v", decoded)
+		}
+	})
+}
+
+func TestEncryptedPDF(t *testing.T) {
+	pages := []string{
+		textPage("WARDEN'S COURT KALGOORLIE"),
+		textPage("OBJECTIONS", "1", "698561", "KARORA (HIGGINSVILLE) PTY LTD", "E 15/2082", "FMG RESOURCES PTY LTD"),
+	}
+
+	read := func(data []byte, password string) (*CauseList, error) {
+		opts := DefaultReadOptions()
+		opts.Password = password
+		cl := NewCauseList("Western Australia", "Kalgoorlie", time.Now())
+		err := cl.ReadCauseListOptions(context.Background(), bytes.NewReader(data), int64(len(data)), opts)
+		return cl, err
+	}
+
+	userLocked := buildTestPDF(newTestPDFEncryption("sesame", "owner"), pages, nil)
+
+	t.Run("password required", func(t *testing.T) {
+		_, err := read(userLocked, "")
+		if !errors.Is(err, ErrEncryptedPDF) || errors.Is(err, ErrWrongPassword) {
+			t.Fatalf("Expected ErrEncryptedPDF, got %v", err)
+		}
+	})
+
+	t.Run("wrong password", func(t *testing.T) {
+		_, err := read(userLocked, "open")
+		if !errors.Is(err, ErrEncryptedPDF) || !errors.Is(err, ErrWrongPassword) {
+			t.Fatalf("Expected ErrWrongPassword, got %v", err)
+		}
+	})
+
+	t.Run("user password", func(t *testing.T) {
+		cl, err := read(userLocked, "sesame")
+		if err != nil {
+			t.Fatalf("Failed to read encrypted PDF: %v", err)
+		}
+		if len(cl.Items) != 1 || cl.Items[0].GetTenementNumber() != "E 15/2082" {
+			t.Fatalf("Expected the decrypted objection, got %v", cl.Items)
+		}
+	})
+
+	t.Run("owner password only", func(t *testing.T) {
+		cl, err := read(buildTestPDF(newTestPDFEncryption("", "owner"), pages, nil), "")
+		if err != nil {
+			t.Fatalf("Failed to read PDF restricted by an owner password: %v", err)
+		}
+		if len(cl.Items) != 1 {
+			t.Fatalf("Expected 1 item, got %d", len(cl.Items))
+		}
+	})
+	t.Run("unsupported encryption", func(t *testing.T) {
+		// Revision 6 (AES-256) is beyond the PDF reader. If this fails, the
+		// reader's message has changed and unsupportedEncryption must follow.
+		data := bytes.Replace(userLocked, []byte("/V 2 /R 3"), []byte("/V 2 /R 6"), 1)
+		if _, err := pdf.NewReader(bytes.NewReader(data), int64(len(data))); !unsupportedEncryption(err) {
+			t.Fatalf("Expected the reader to reject the encryption, got %v", err)
+		}
+		if _, err := read(data, "sesame"); !errors.Is(err, ErrEncryptedPDF) || errors.Is(err, ErrMalformedPDF) {
+			t.Fatalf("Expected ErrEncryptedPDF, got %v", err)
+		}
+		if unsupportedEncryption(nil) || unsupportedEncryption(errors.New("malformed PDF: missing ID in trailer")) {
+			t.Fatalf("Expected only encryption errors to match")
+		}
+	})
+}
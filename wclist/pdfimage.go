@@ -0,0 +1,280 @@
+package wclist
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+
+	"github.com/ledongthuc/pdf"
+)
+
+// maxImageSide bounds the width and height of an image, in pixels
+const maxImageSide = 1 << 16
+
+// maxFlateRatio is the most DEFLATE can compress data by, which bounds the
+// decoded size of a Flate stream by its length
+const maxFlateRatio = 1032
+
+// hasImages reports whether images are drawn on a page
+func hasImages(page pdf.Page) bool {
+	xobjects := page.Resources().Key("XObject")
+	for _, name := range xobjects.Keys() {
+		if xobjects.Key(name).Key("Subtype").Name() == "Image" {
+			return true
+		}
+	}
+	return false
+}
+
+// pageImages returns the images drawn on a page in a form an OCR engine can
+// read. JPEG and JPEG 2000 images are passed on as they are, fax images are
+// wrapped in a TIFF header and uncompressed or Flate images are encoded as PNG.
+func pageImages(doc *pdfDocument, page pdf.Page, i int) ([]PageImage, error) {
+	xobjects := page.Resources().Key("XObject")
+
+	var images []PageImage
+	for _, name := range xobjects.Keys() {
+		xobject := xobjects.Key(name)
+		if xobject.Key("Subtype").Name() != "Image" {
+			continue
+		}
+
+		width, height := xobject.Key("Width").Int64(), xobject.Key("Height").Int64()
+		if width <= 0 || height <= 0 || width > maxImageSide || height > maxImageSide {
+			return nil, fmt.Errorf("image %s: invalid size %dx%d", name, width, height)
+		}
+		img := PageImage{Page: i, Width: int(width), Height: int(height)}
+
+		var err error
+		switch filter := imageFilter(xobject); filter {
+		case "DCTDecode":
+			img.Format = "jpeg"
+			img.Data, err = doc.rawImage(i, name)
+		case "JPXDecode":
+			img.Format = "jp2"
+			img.Data, err = doc.rawImage(i, name)
+		case "CCITTFaxDecode":
+			img.Format = "tiff"
+			var data []byte
+			if data, err = doc.rawImage(i, name); err == nil {
+				img.Data = ccittTIFF(data, img.Width, img.Height, xobject.Key("DecodeParms"))
+			}
+		case "", "FlateDecode":
+			img.Format = "png"
+			img.Data, err = pixelsPNG(xobject, img.Width, img.Height, doc.opts.MaxBytes)
+		default:
+			err = fmt.Errorf("unsupported image filter %s", filter)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("image %s: %w", name, err)
+		}
+		images = append(images, img)
+	}
+
+	return images, nil
+}
+
+// imageFilter returns the filter an image is compressed with. Images with a
+// chain of filters are named by the last one, which determines the image format.
+func imageFilter(xobject pdf.Value) string {
+	filter := xobject.Key("Filter")
+	if filter.Kind() == pdf.Array {
+		if filter.Len() == 0 {
+			return ""
+		}
+		return filter.Index(filter.Len() - 1).Name()
+	}
+	return filter.Name()
+}
+
+// rawImage returns the undecoded data of an image drawn on page i. The PDF
+// reader only returns decoded stream data and can't decode image formats
+// such as JPEG, so the image is found in the file's own objects and read
+// from there, within the document and opts.MaxBytes.
+func (doc *pdfDocument) rawImage(i int, name string) ([]byte, error) {
+	if !doc.reader.Trailer().Key("Encrypt").IsNull() {
+		return nil, fmt.Errorf("images in encrypted PDFs can't be read")
+	}
+	objects := doc.objects
+	pages, err := doc.pages()
+	if err != nil {
+		return nil, err
+	}
+	if i < 1 || i > len(pages) {
+		return nil, fmt.Errorf("page %d not found in the page tree", i)
+	}
+
+	value, err := objects.resolve(pages[i-1].resources)
+	if err != nil {
+		return nil, err
+	}
+	resources, err := parsePDFDict(value)
+	if err != nil {
+		return nil, err
+	}
+	xobjects, err := objects.dict(resources, "XObject")
+	if err != nil {
+		return nil, err
+	}
+	for _, entry := range xobjects {
+		if pdfNameDecode(entry.key) != name {
+			continue
+		}
+		ref, err := parseRef(entry.value)
+		if err != nil {
+			return nil, err
+		}
+		object, err := objects.object(ref)
+		if err != nil {
+			return nil, err
+		}
+		dict, err := parsePDFDict(object.value)
+		if err != nil || object.data < 0 {
+			return nil, fmt.Errorf("%w: image %s is not a stream", ErrMalformedPDF, name)
+		}
+		length, err := objects.int(dict, "Length")
+		if err != nil {
+			return nil, err
+		}
+		return objects.streamData(object.data, length, doc.opts.MaxBytes)
+	}
+	return nil, fmt.Errorf("image %s not found", name)
+}
+
+// pixelsPNG encodes an image stored as raw pixels as a PNG. Greyscale and RGB
+// images with 1 or 8 bits per component are supported. The pixels must fit
+// in the stream, allowing for compression, and in maxBytes when it is
+// positive.
+func pixelsPNG(xobject pdf.Value, width, height int, maxBytes int64) ([]byte, error) {
+	colorSpace := xobject.Key("ColorSpace").Name()
+	bits := int(xobject.Key("BitsPerComponent").Int64())
+	if xobject.Key("ImageMask").Bool() {
+		colorSpace, bits = "DeviceGray", 1
+	}
+
+	components := map[string]int{"DeviceGray": 1, "DeviceRGB": 3}[colorSpace]
+	if components == 0 || (bits != 1 && bits != 8) || (bits == 1 && components != 1) {
+		return nil, fmt.Errorf("unsupported image colour space %s with %d bits per component", colorSpace, bits)
+	}
+
+	// Width and height are at most maxImageSide, so the size can't overflow
+	stride := (width*components*bits + 7) / 8
+	size := int64(stride) * int64(height)
+	limit := xobject.Key("Length").Int64()
+	if imageFilter(xobject) == "FlateDecode" {
+		limit *= maxFlateRatio
+	}
+	if size > limit {
+		return nil, fmt.Errorf("%w: image data is shorter than %dx%d pixels", ErrMalformedPDF, width, height)
+	}
+	if maxBytes > 0 && size > maxBytes {
+		return nil, fmt.Errorf("%w: image of %d bytes", ErrTooLarge, size)
+	}
+
+	pixels := make([]byte, size)
+	if _, err := io.ReadFull(xobject.Reader(), pixels); err != nil {
+		return nil, fmt.Errorf("reading image data: %w", err)
+	}
+
+	var img image.Image
+	switch {
+	case bits == 1:
+		gray := image.NewGray(image.Rect(0, 0, width, height))
+		for y := 0; y < height; y++ {
+			for x := 0; x < width; x++ {
+				if pixels[y*stride+x/8]&(0x80>>(x%8)) != 0 {
+					gray.Pix[y*gray.Stride+x] = 0xff
+				}
+			}
+		}
+		img = gray
+	case components == 1:
+		img = &image.Gray{Pix: pixels, Stride: stride, Rect: image.Rect(0, 0, width, height)}
+	default:
+		rgba := image.NewRGBA(image.Rect(0, 0, width, height))
+		for y := 0; y < height; y++ {
+			for x := 0; x < width; x++ {
+				p := pixels[y*stride+3*x:]
+				rgba.Set(x, y, color.RGBA{p[0], p[1], p[2], 0xff})
+			}
+		}
+		img = rgba
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// ccittTIFF wraps CCITT fax data in a single-strip TIFF file
+func ccittTIFF(data []byte, width, height int, params pdf.Value) []byte {
+	if columns := params.Key("Columns").Int64(); columns > 0 {
+		width = int(columns)
+	}
+	if rows := params.Key("Rows").Int64(); rows > 0 {
+		height = int(rows)
+	}
+
+	// K < 0 is pure two-dimensional (Group 4) coding; otherwise Group 3,
+	// with two-dimensional coding when K > 0
+	k := params.Key("K").Int64()
+	compression, t4Options := uint32(3), uint32(0)
+	if k < 0 {
+		compression = 4
+	} else if k > 0 {
+		t4Options = 1
+	}
+
+	type entry struct {
+		tag, kind uint16
+		value     uint32
+	}
+	const (
+		short = 3
+		long  = 4
+	)
+	entries := []entry{
+		{256, long, uint32(width)},  // ImageWidth
+		{257, long, uint32(height)}, // ImageLength
+		{258, short, 1},             // BitsPerSample
+		{259, short, compression},   // Compression
+		{262, short, 0},             // PhotometricInterpretation: WhiteIsZero
+		{273, long, 0},              // StripOffsets, set below
+		{278, long, uint32(height)}, // RowsPerStrip
+		{279, long, uint32(len(data))},
+	}
+	if compression == 3 {
+		entries = append(entries, entry{292, long, t4Options}) // T4Options
+	}
+
+	// Header, then the directory, then the image data
+	ifdSize := 2 + 12*len(entries) + 4
+	dataOffset := uint32(8 + ifdSize)
+	entries[5].value = dataOffset
+
+	var buf bytes.Buffer
+	buf.WriteString("II*\x00")
+	binary.Write(&buf, binary.LittleEndian, uint32(8))
+	binary.Write(&buf, binary.LittleEndian, uint16(len(entries)))
+	for _, e := range entries {
+		binary.Write(&buf, binary.LittleEndian, e.tag)
+		binary.Write(&buf, binary.LittleEndian, e.kind)
+		binary.Write(&buf, binary.LittleEndian, uint32(1))
+		if e.kind == short {
+			binary.Write(&buf, binary.LittleEndian, uint16(e.value))
+			binary.Write(&buf, binary.LittleEndian, uint16(0))
+		} else {
+			binary.Write(&buf, binary.LittleEndian, e.value)
+		}
+	}
+	binary.Write(&buf, binary.LittleEndian, uint32(0)) // No more directories
+	buf.Write(data)
+
+	return buf.Bytes()
+}
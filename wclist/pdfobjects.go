@@ -0,0 +1,1029 @@
+package wclist
+
+import (
+	"bytes"
+	"compress/zlib"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// maxObjectStream bounds the decoded size of a compressed object stream or
+// cross-reference stream
+const maxObjectStream = 64 << 20
+
+// pdfObjects reads the objects of a PDF in their own syntax. The PDF reader
+// resolves references as it goes and doesn't expose object numbers or the
+// undecoded data of streams, which updating a PDF and passing images on to
+// OCR both need. Encrypted strings and streams are returned as they are.
+type pdfObjects struct {
+	r       io.ReaderAt
+	size    int64
+	xref    map[int]xrefEntry
+	bounds  []int64     // Sorted offsets of the objects, bounding each one
+	trailer []dictEntry // The newest trailer
+
+	streams *objectStreams
+
+	// resolving lists the object streams being read by this lookup, so
+	// that a stream whose dictionary refers back into itself is refused
+	resolving []int
+}
+
+// objectStreams holds the decoded object streams of a PDF by number
+type objectStreams struct {
+	mu      sync.Mutex
+	decoded map[int]objectStream
+}
+
+// objectStream is a decoded object stream, with the offset of its first
+// object
+type objectStream struct {
+	data  []byte
+	first int64
+}
+
+// xrefEntry locates an object at an offset in the file, or by its index in
+// an object stream
+type xrefEntry struct {
+	offset int64
+	stream int // Object stream holding the object, or 0
+	free   bool
+}
+
+// pdfObject is an object read from a PDF
+type pdfObject struct {
+	value string // The object, or a stream's dictionary, in PDF syntax
+	data  int64  // Offset of a stream's data, or -1 if it isn't a stream
+}
+
+// newPDFObjects reads the cross-reference sections of a PDF, newest first,
+// until the context is cancelled
+func newPDFObjects(ctx context.Context, r io.ReaderAt, size int64) (*pdfObjects, error) {
+	offset, err := lastStartXref(r, size)
+	if err != nil {
+		return nil, err
+	}
+
+	o := &pdfObjects{r: r, size: size, xref: make(map[int]xrefEntry), streams: &objectStreams{decoded: make(map[int]objectStream)}}
+	seen := make(map[int64]bool)
+	for pending := []int64{offset}; len(pending) > 0; {
+		offset, pending = pending[0], pending[1:]
+		if seen[offset] {
+			continue
+		}
+		seen[offset] = true
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		trailer, err := o.readXref(ctx, offset)
+		if err != nil {
+			return nil, err
+		}
+		if o.trailer == nil {
+			o.trailer = trailer
+		}
+		// A hybrid file's cross-reference stream takes precedence over the
+		// table that declares it, but not over newer sections
+		for _, key := range []string{"XRefStm", "Prev"} {
+			if value, ok := dictValue(trailer, key); ok {
+				next, err := strconv.ParseInt(value, 10, 64)
+				if err != nil || next < 0 || next >= size {
+					return nil, fmt.Errorf("%w: invalid /%s", ErrMalformedPDF, key)
+				}
+				pending = append(pending, next)
+			}
+		}
+	}
+
+	for _, entry := range o.xref {
+		if !entry.free && entry.stream == 0 {
+			o.bounds = append(o.bounds, entry.offset)
+		}
+	}
+	slices.Sort(o.bounds)
+	return o, nil
+}
+
+// checkedPDFObjects reads the objects of a PDF like newPDFObjects and checks
+// its object streams with checkObjectStreams. The PDF reader follows object
+// streams without noticing cycles, and reads cross-reference streams for as
+// many entries as they claim, so PDFs are checked here before it is given
+// them.
+func checkedPDFObjects(ctx context.Context, r io.ReaderAt, size int64) (*pdfObjects, error) {
+	o, err := newPDFObjects(ctx, r, size)
+	if err != nil {
+		return nil, err
+	}
+	if err := o.checkObjectStreams(ctx); err != nil {
+		return nil, err
+	}
+	return o, nil
+}
+
+// refPattern matches the references in a value in PDF syntax
+var refPattern = regexp.MustCompile(`\b(\d+)\s+\d+\s+R\b`)
+
+// checkObjectStreams reports ErrMalformedPDF when reading an object from an
+// object stream leads back to the same stream: when the stream is itself
+// compressed, when a value in its dictionary refers to an object compressed
+// in it, or when it extends a stream that does either. Only dictionaries are
+// read, so encrypted PDFs are checked too.
+func (o *pdfObjects) checkObjectStreams(ctx context.Context) error {
+	const (
+		visiting = iota + 1
+		visited
+	)
+	state := make(map[int]int)
+	var visit func(stream int) error
+	visit = func(stream int) error {
+		switch state[stream] {
+		case visiting:
+			return fmt.Errorf("%w: object stream %d contains itself", ErrMalformedPDF, stream)
+		case visited:
+			return nil
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		state[stream] = visiting
+		for _, dep := range o.streamDeps(stream) {
+			if err := visit(dep); err != nil {
+				return err
+			}
+		}
+		state[stream] = visited
+		return nil
+	}
+	for _, entry := range o.xref {
+		if entry.stream != 0 {
+			if err := visit(entry.stream); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// streamDeps returns the object streams that reading from an object stream
+// reads first: the streams holding the objects its dictionary refers to,
+// and the object streams it refers to, such as the one it extends. The PDF
+// reader resolves the values of the dictionary and of the dictionaries they
+// refer to, such as /DecodeParms, so references are followed two deep.
+func (o *pdfObjects) streamDeps(stream int) []int {
+	type pending struct{ num, depth int }
+	var deps []int
+	seen := make(map[int]bool)
+	for queue := []pending{{stream, 0}}; len(queue) > 0; queue = queue[1:] {
+		next := queue[0]
+		entry, ok := o.xref[next.num]
+		switch {
+		case seen[next.num] || !ok || entry.free:
+			continue
+		case entry.stream != 0:
+			deps = append(deps, entry.stream)
+			continue
+		}
+		seen[next.num] = true
+		object, err := o.objectAt(entry.offset)
+		if err != nil {
+			// Left for the PDF reader to report
+			continue
+		}
+		if dict, err := parsePDFDict(object.value); next.depth > 0 && err == nil && dictName(dict, "Type") == "ObjStm" {
+			deps = append(deps, next.num)
+			continue
+		}
+		if next.depth == 2 {
+			continue
+		}
+		for _, m := range refPattern.FindAllStringSubmatch(object.value, -1) {
+			if num, err := strconv.Atoi(m[1]); err == nil {
+				queue = append(queue, pending{num, next.depth + 1})
+			}
+		}
+	}
+	return deps
+}
+
+// addXref records an entry unless a newer section already has one
+func (o *pdfObjects) addXref(num int, entry xrefEntry) {
+	if _, ok := o.xref[num]; !ok {
+		o.xref[num] = entry
+	}
+}
+
+var errNotXrefTable = errors.New("not a cross-reference table")
+
+// readXref reads the cross-reference section at offset, a table or a
+// stream, and returns its trailer
+func (o *pdfObjects) readXref(ctx context.Context, offset int64) ([]dictEntry, error) {
+	type entry struct {
+		num int
+		xrefEntry
+	}
+	var entries []entry
+	var trailer string
+	err := o.scanAt(offset, o.size, func(l *pdfLexer) error {
+		entries, trailer = entries[:0], ""
+		if l.keyword() != "xref" {
+			return errNotXrefTable
+		}
+		for {
+			token := l.keyword()
+			if token == "trailer" {
+				var err error
+				trailer, err = l.value()
+				return err
+			}
+			start, err1 := strconv.Atoi(token)
+			count, err2 := strconv.Atoi(l.keyword())
+			if err1 != nil || err2 != nil || start < 0 || count < 0 {
+				return l.truncatedOr("invalid cross-reference subsection")
+			}
+			for num := start; num < start+count; num++ {
+				position, err := strconv.ParseInt(l.keyword(), 10, 64)
+				l.keyword() // Generation
+				switch kind := l.keyword(); {
+				case kind == "n" && err == nil:
+					entries = append(entries, entry{num, xrefEntry{offset: position}})
+				case kind == "f":
+					entries = append(entries, entry{num, xrefEntry{free: true}})
+				default:
+					return l.truncatedOr("invalid cross-reference entry")
+				}
+			}
+		}
+	})
+	if errors.Is(err, errNotXrefTable) {
+		return o.readXrefStream(ctx, offset)
+	}
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range entries {
+		o.addXref(e.num, e.xrefEntry)
+	}
+	return parsePDFDict(trailer)
+}
+
+// readXrefStream reads a cross-reference stream, whose dictionary is also
+// its section's trailer. It has no more entries than its rows of data hold.
+func (o *pdfObjects) readXrefStream(ctx context.Context, offset int64) ([]dictEntry, error) {
+	object, err := o.objectAt(offset)
+	if err != nil {
+		return nil, err
+	}
+	dict, err := parsePDFDict(object.value)
+	if err != nil {
+		return nil, err
+	}
+	if object.data < 0 || dictName(dict, "Type") != "XRef" {
+		return nil, fmt.Errorf("%w: no cross-reference section at %d", ErrMalformedPDF, offset)
+	}
+	data, err := o.decodeStream(dict, object.data)
+	if err != nil {
+		return nil, err
+	}
+
+	widths, err := o.ints(dict, "W")
+	if err != nil || len(widths) != 3 {
+		return nil, fmt.Errorf("%w: invalid cross-reference stream /W", ErrMalformedPDF)
+	}
+	row := int64(0)
+	for _, w := range widths {
+		if w < 0 || w > 8 {
+			return nil, fmt.Errorf("%w: invalid cross-reference stream /W", ErrMalformedPDF)
+		}
+		row += w
+	}
+	if row == 0 {
+		return nil, fmt.Errorf("%w: invalid cross-reference stream /W", ErrMalformedPDF)
+	}
+	index, err := o.ints(dict, "Index")
+	if err != nil {
+		size, _ := o.int(dict, "Size")
+		index = []int64{0, size}
+	}
+	entries := int64(0)
+	for i := 0; i+1 < len(index); i += 2 {
+		if index[i] < 0 || index[i+1] < 0 || index[i+1] > int64(len(data))/row-entries {
+			return nil, fmt.Errorf("%w: cross-reference stream /Index exceeds its data", ErrMalformedPDF)
+		}
+		entries += index[i+1]
+	}
+
+	// field reads a big-endian field, which defaults to def when absent
+	field := func(b []byte, def int64) int64 {
+		if len(b) == 0 {
+			return def
+		}
+		var v int64
+		for _, c := range b {
+			v = v<<8 | int64(c)
+		}
+		return v
+	}
+	for i := 0; i+1 < len(index); i += 2 {
+		for num := index[i]; num < index[i]+index[i+1]; num++ {
+			if num%4096 == 0 {
+				if err := ctx.Err(); err != nil {
+					return nil, err
+				}
+			}
+			fields := data[:row]
+			data = data[row:]
+
+			kind := field(fields[:widths[0]], 1)
+			second := field(fields[widths[0]:widths[0]+widths[1]], 0)
+			third := field(fields[widths[0]+widths[1]:], 0)
+			switch kind {
+			case 0:
+				o.addXref(int(num), xrefEntry{free: true})
+			case 1:
+				o.addXref(int(num), xrefEntry{offset: second})
+			case 2:
+				o.addXref(int(num), xrefEntry{offset: third, stream: int(second)})
+			}
+		}
+	}
+	return dict, nil
+}
+
+// object reads an object by its reference
+func (o *pdfObjects) object(ref pdfRef) (pdfObject, error) {
+	entry, ok := o.xref[ref.num]
+	switch {
+	case !ok || entry.free:
+		// References to missing objects are references to null
+		return pdfObject{value: "null", data: -1}, nil
+	case entry.stream != 0:
+		return o.compressedObject(entry)
+	}
+	return o.objectAt(entry.offset)
+}
+
+// objectAt reads the object at offset, which extends at most to the next
+// object in the file
+func (o *pdfObjects) objectAt(offset int64) (pdfObject, error) {
+	if offset < 0 || offset >= o.size {
+		return pdfObject{}, fmt.Errorf("%w: object offset %d out of range", ErrMalformedPDF, offset)
+	}
+	end := o.size
+	if i, _ := slices.BinarySearch(o.bounds, offset+1); i < len(o.bounds) {
+		end = o.bounds[i]
+	}
+
+	var object pdfObject
+	err := o.scanAt(offset, end, func(l *pdfLexer) error {
+		_, err1 := strconv.Atoi(l.keyword())
+		_, err2 := strconv.Atoi(l.keyword())
+		if err1 != nil || err2 != nil || l.keyword() != "obj" {
+			return l.truncatedOr("expected an object")
+		}
+		value, err := l.value()
+		if err != nil {
+			return err
+		}
+		object = pdfObject{value: value, data: -1}
+
+		// A stream's data starts after the end of line following "stream"
+		mark := l.pos
+		keyword := l.keyword()
+		if l.eof && l.more {
+			return errTruncated
+		}
+		if !strings.HasPrefix(value, "<<") || keyword != "stream" {
+			l.pos = mark
+			return nil
+		}
+		switch {
+		case bytes.HasPrefix(l.data[l.pos:], []byte("\r\n")):
+			l.pos += 2
+		case l.pos < len(l.data) && (l.data[l.pos] == '\n' || l.data[l.pos] == '\r'):
+			l.pos++
+		case l.pos >= len(l.data)-1:
+			return errTruncated
+		}
+		object.data = offset + int64(l.pos)
+		return nil
+	})
+	return object, err
+}
+
+// compressedObject reads an object from an object stream. Object streams
+// must not themselves be compressed, and the values of their dictionaries
+// are looked up without reading the stream again, so that a stream that
+// refers to itself is refused rather than read forever.
+func (o *pdfObjects) compressedObject(entry xrefEntry) (pdfObject, error) {
+	invalid := fmt.Errorf("%w: invalid object stream %d", ErrMalformedPDF, entry.stream)
+
+	o.streams.mu.Lock()
+	stream, ok := o.streams.decoded[entry.stream]
+	o.streams.mu.Unlock()
+	if !ok {
+		if o.xref[entry.stream].stream != 0 {
+			return pdfObject{}, fmt.Errorf("%w: object stream %d is itself compressed", ErrMalformedPDF, entry.stream)
+		}
+		if slices.Contains(o.resolving, entry.stream) {
+			return pdfObject{}, fmt.Errorf("%w: object stream %d refers to itself", ErrMalformedPDF, entry.stream)
+		}
+		lookup := *o
+		lookup.resolving = append(slices.Clip(o.resolving), entry.stream)
+
+		object, err := lookup.object(pdfRef{num: entry.stream})
+		if err != nil {
+			return pdfObject{}, err
+		}
+		dict, err := parsePDFDict(object.value)
+		if err != nil || object.data < 0 {
+			return pdfObject{}, invalid
+		}
+		if stream.first, err = lookup.int(dict, "First"); err != nil {
+			return pdfObject{}, err
+		}
+		if stream.data, err = lookup.decodeStream(dict, object.data); err != nil {
+			return pdfObject{}, err
+		}
+		o.streams.mu.Lock()
+		o.streams.decoded[entry.stream] = stream
+		o.streams.mu.Unlock()
+	}
+
+	// The stream starts with pairs of object numbers and offsets
+	l := &pdfLexer{data: stream.data}
+	offset := int64(-1)
+	for i := int64(0); i <= entry.offset; i++ {
+		l.keyword()
+		n, err := strconv.ParseInt(l.keyword(), 10, 64)
+		if err != nil {
+			return pdfObject{}, invalid
+		}
+		offset = n
+	}
+	start := stream.first + offset
+	if stream.first < 0 || offset < 0 || start >= int64(len(stream.data)) {
+		return pdfObject{}, invalid
+	}
+	l = &pdfLexer{data: stream.data, pos: int(start)}
+	value, err := l.value()
+	if err != nil {
+		return pdfObject{}, invalid
+	}
+	return pdfObject{value: value, data: -1}, nil
+}
+
+// scanAt reads the file from offset up to end, growing the part read until
+// scan stops asking for more
+func (o *pdfObjects) scanAt(offset, end int64, scan func(l *pdfLexer) error) error {
+	for n := int64(4096); ; n *= 2 {
+		n = min(n, end-offset)
+		data := make([]byte, n)
+		if read, err := o.r.ReadAt(data, offset); int64(read) < n {
+			return fmt.Errorf("%w: %w", ErrMalformedPDF, err)
+		}
+		l := &pdfLexer{data: data, more: offset+n < end}
+		err := scan(l)
+		if !errors.Is(err, errTruncated) {
+			return err
+		}
+		if !l.more {
+			return fmt.Errorf("%w: unexpected end of file", ErrMalformedPDF)
+		}
+	}
+}
+
+// streamData reads the undecoded data of a stream, refusing streams longer
+// than limit when it is positive
+func (o *pdfObjects) streamData(offset, length, limit int64) ([]byte, error) {
+	if length < 0 || offset < 0 || length > o.size-offset {
+		return nil, fmt.Errorf("%w: stream length %d out of range", ErrMalformedPDF, length)
+	}
+	if limit > 0 && length > limit {
+		return nil, fmt.Errorf("%w: stream of %d bytes", ErrTooLarge, length)
+	}
+	data := make([]byte, length)
+	if read, err := o.r.ReadAt(data, offset); int64(read) < length {
+		return nil, fmt.Errorf("%w: %w", ErrMalformedPDF, err)
+	}
+	return data, nil
+}
+
+// decodeStream reads and decodes a Flate-compressed or uncompressed stream,
+// up to maxObjectStream bytes
+func (o *pdfObjects) decodeStream(dict []dictEntry, offset int64) ([]byte, error) {
+	length, err := o.int(dict, "Length")
+	if err != nil {
+		return nil, err
+	}
+	data, err := o.streamData(offset, length, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	filter, _ := dictValue(dict, "Filter")
+	switch strings.Trim(filter, "[ ]") {
+	case "":
+		return data, nil
+	case "/FlateDecode":
+	default:
+		return nil, fmt.Errorf("%w: unsupported stream filter %s", ErrMalformedPDF, filter)
+	}
+	zr, err := zlib.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrMalformedPDF, err)
+	}
+	if data, err = io.ReadAll(io.LimitReader(zr, maxObjectStream+1)); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrMalformedPDF, err)
+	}
+	if len(data) > maxObjectStream {
+		return nil, fmt.Errorf("%w: stream decodes to over %d bytes", ErrTooLarge, maxObjectStream)
+	}
+
+	params, err := o.dict(dict, "DecodeParms")
+	if err != nil || params == nil {
+		return data, err
+	}
+	predictor, _ := o.int(params, "Predictor")
+	switch {
+	case predictor <= 1:
+		return data, nil
+	case predictor >= 10:
+		columns, err := o.int(params, "Columns")
+		if err != nil || columns < 1 {
+			columns = 1
+		}
+		return pngUnpredict(data, int(columns))
+	}
+	return nil, fmt.Errorf("%w: unsupported predictor %d", ErrMalformedPDF, predictor)
+}
+
+// pngUnpredict reverses PNG prediction of rows of one-byte samples, each
+// row starting with the type of prediction used for it
+func pngUnpredict(data []byte, columns int) ([]byte, error) {
+	if columns > len(data) {
+		return nil, fmt.Errorf("%w: predictor columns exceed the stream", ErrMalformedPDF)
+	}
+	out := make([]byte, 0, len(data)/(columns+1)*columns)
+	prev := make([]byte, columns)
+	for len(data) >= columns+1 {
+		kind, row := data[0], data[1:columns+1]
+		data = data[columns+1:]
+		for i := range row {
+			var left, upLeft byte
+			if i > 0 {
+				left, upLeft = row[i-1], prev[i-1]
+			}
+			up := prev[i]
+			switch kind {
+			case 0:
+			case 1:
+				row[i] += left
+			case 2:
+				row[i] += up
+			case 3:
+				row[i] += byte((int(left) + int(up)) / 2)
+			case 4:
+				row[i] += paeth(left, up, upLeft)
+			default:
+				return nil, fmt.Errorf("%w: invalid PNG predictor %d", ErrMalformedPDF, kind)
+			}
+		}
+		out = append(out, row...)
+		prev = row
+	}
+	return out, nil
+}
+
+// paeth is the PNG Paeth predictor
+func paeth(a, b, c byte) byte {
+	p := int(a) + int(b) - int(c)
+	pa, pb, pc := abs(p-int(a)), abs(p-int(b)), abs(p-int(c))
+	switch {
+	case pa <= pb && pa <= pc:
+		return a
+	case pb <= pc:
+		return b
+	}
+	return c
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+// resolve returns the value a reference refers to, or the value itself
+func (o *pdfObjects) resolve(value string) (string, error) {
+	ref, err := parseRef(value)
+	if err != nil {
+		return value, nil
+	}
+	object, err := o.object(ref)
+	return object.value, err
+}
+
+// int returns an integer entry of a dictionary
+func (o *pdfObjects) int(dict []dictEntry, key string) (int64, error) {
+	value, ok := dictValue(dict, key)
+	if !ok {
+		return 0, fmt.Errorf("%w: missing /%s", ErrMalformedPDF, key)
+	}
+	value, err := o.resolve(value)
+	if err != nil {
+		return 0, err
+	}
+	n, err := strconv.ParseInt(strings.TrimSpace(value), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%w: /%s is not an integer", ErrMalformedPDF, key)
+	}
+	return n, nil
+}
+
+// ints returns an entry of a dictionary that is an array of integers
+func (o *pdfObjects) ints(dict []dictEntry, key string) ([]int64, error) {
+	value, ok := dictValue(dict, key)
+	if !ok {
+		return nil, fmt.Errorf("%w: missing /%s", ErrMalformedPDF, key)
+	}
+	value, err := o.resolve(value)
+	if err != nil {
+		return nil, err
+	}
+	elements, err := parsePDFArray(value)
+	if err != nil {
+		return nil, err
+	}
+	ints := make([]int64, len(elements))
+	for i, element := range elements {
+		if ints[i], err = strconv.ParseInt(element, 10, 64); err != nil {
+			return nil, fmt.Errorf("%w: /%s is not an array of integers", ErrMalformedPDF, key)
+		}
+	}
+	return ints, nil
+}
+
+// dict returns an entry of a dictionary that is itself a dictionary, or nil
+// if there is no such entry
+func (o *pdfObjects) dict(dict []dictEntry, key string) ([]dictEntry, error) {
+	value, ok := dictValue(dict, key)
+	if !ok {
+		return nil, nil
+	}
+	value, err := o.resolve(value)
+	if err != nil || value == "null" {
+		return nil, err
+	}
+	return parsePDFDict(value)
+}
+
+// pageNode is a page in the page tree of a PDF along with the resources it
+// inherits
+type pageNode struct {
+	ref       pdfRef
+	resources string // In PDF syntax; may be a reference
+}
+
+// pages walks the page tree, returning its root and the pages in order
+func (o *pdfObjects) pages() (pdfRef, []pageNode, error) {
+	catalog, err := o.dict(o.trailer, "Root")
+	if err != nil {
+		return pdfRef{}, nil, err
+	}
+	value, _ := dictValue(catalog, "Pages")
+	root, err := parseRef(value)
+	if err != nil {
+		return pdfRef{}, nil, fmt.Errorf("%w: catalog has no page tree", ErrMalformedPDF)
+	}
+
+	var pages []pageNode
+	seen := make(map[pdfRef]bool)
+	var walk func(ref pdfRef, resources string) error
+	walk = func(ref pdfRef, resources string) error {
+		if seen[ref] {
+			return fmt.Errorf("%w: page tree has a cycle", ErrMalformedPDF)
+		}
+		seen[ref] = true
+
+		object, err := o.object(ref)
+		if err != nil {
+			return err
+		}
+		node, err := parsePDFDict(object.value)
+		if err != nil {
+			return err
+		}
+		if value, ok := dictValue(node, "Resources"); ok {
+			resources = value
+		}
+		if dictName(node, "Type") != "Pages" {
+			pages = append(pages, pageNode{ref: ref, resources: resources})
+			return nil
+		}
+
+		value, _ := dictValue(node, "Kids")
+		if value, err = o.resolve(value); err != nil {
+			return err
+		}
+		kids, err := parsePDFArray(value)
+		if err != nil {
+			return err
+		}
+		for _, kid := range kids {
+			ref, err := parseRef(kid)
+			if err != nil {
+				return fmt.Errorf("%w: page tree kids must be references", ErrMalformedPDF)
+			}
+			if err := walk(ref, resources); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if err := walk(root, ""); err != nil {
+		return pdfRef{}, nil, err
+	}
+	return root, pages, nil
+}
+
+// parseRef reads a reference written "n g R"
+func parseRef(s string) (pdfRef, error) {
+	fields := strings.Fields(s)
+	if len(fields) == 3 && fields[2] == "R" {
+		num, err1 := strconv.Atoi(fields[0])
+		gen, err2 := strconv.Atoi(fields[1])
+		if err1 == nil && err2 == nil && num >= 0 && gen >= 0 {
+			return pdfRef{num, gen}, nil
+		}
+	}
+	return pdfRef{}, fmt.Errorf("%w: expected a reference, got %.40q", ErrMalformedPDF, s)
+}
+
+// dictValue returns the value of an entry of a dictionary
+func dictValue(dict []dictEntry, key string) (string, bool) {
+	for _, entry := range dict {
+		if entry.key == key {
+			return entry.value, true
+		}
+	}
+	return "", false
+}
+
+// dictName returns the value of an entry of a dictionary that is a name,
+// without its slash
+func dictName(dict []dictEntry, key string) string {
+	value, _ := dictValue(dict, key)
+	if !strings.HasPrefix(value, "/") {
+		return ""
+	}
+	return pdfNameDecode(value[1:])
+}
+
+// pdfNameDecode decodes the #xx escapes of a name
+func pdfNameDecode(name string) string {
+	if !strings.Contains(name, "#") {
+		return name
+	}
+	var b strings.Builder
+	for i := 0; i < len(name); i++ {
+		if name[i] == '#' && i+2 < len(name) {
+			if c, err := strconv.ParseUint(name[i+1:i+3], 16, 8); err == nil {
+				b.WriteByte(byte(c))
+				i += 2
+				continue
+			}
+		}
+		b.WriteByte(name[i])
+	}
+	return b.String()
+}
+
+// parsePDFDict reads the entries of a dictionary in PDF syntax, keeping
+// each value in PDF syntax
+func parsePDFDict(s string) ([]dictEntry, error) {
+	l := &pdfLexer{data: []byte(s)}
+	l.skipSpace()
+	if !bytes.HasPrefix(l.data[l.pos:], []byte("<<")) {
+		return nil, fmt.Errorf("%w: expected a dictionary, got %.40q", ErrMalformedPDF, s)
+	}
+	l.pos += 2
+
+	var entries []dictEntry
+	for {
+		l.skipSpace()
+		switch {
+		case bytes.HasPrefix(l.data[l.pos:], []byte(">>")):
+			return entries, nil
+		case l.pos >= len(l.data) || l.data[l.pos] != '/':
+			return nil, fmt.Errorf("%w: expected a name in %.40q", ErrMalformedPDF, s)
+		}
+		l.pos++
+		key := l.token()
+		value, err := l.value()
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, dictEntry{key, value})
+	}
+}
+
+// parsePDFArray reads the elements of an array in PDF syntax, keeping each
+// element in PDF syntax
+func parsePDFArray(s string) ([]string, error) {
+	l := &pdfLexer{data: []byte(s)}
+	l.skipSpace()
+	if l.pos >= len(l.data) || l.data[l.pos] != '[' {
+		return nil, fmt.Errorf("%w: expected an array, got %.40q", ErrMalformedPDF, s)
+	}
+	l.pos++
+
+	var elements []string
+	for {
+		l.skipSpace()
+		if l.pos < len(l.data) && l.data[l.pos] == ']' {
+			return elements, nil
+		}
+		element, err := l.value()
+		if err != nil {
+			return nil, err
+		}
+		elements = append(elements, element)
+	}
+}
+
+// pdfLexer reads values in PDF syntax (PDF 32000-1:2008, §7.2 and §7.3)
+type pdfLexer struct {
+	data []byte
+	pos  int
+	more bool // Whether the data continues past its end
+	eof  bool // Whether a token ran to the end of the data
+}
+
+// errTruncated reports that a value runs past the end of the data read so
+// far, and more is needed
+var errTruncated = errors.New("truncated")
+
+func isPDFSpace(c byte) bool {
+	return c == 0 || c == '\t' || c == '\n' || c == '\f' || c == '\r' || c == ' '
+}
+
+func isPDFDelimiter(c byte) bool {
+	return strings.IndexByte("()<>[]{}/%", c) >= 0
+}
+
+// skipSpace skips white space and comments
+func (l *pdfLexer) skipSpace() {
+	for l.pos < len(l.data) {
+		switch c := l.data[l.pos]; {
+		case isPDFSpace(c):
+			l.pos++
+		case c == '%':
+			for l.pos < len(l.data) && l.data[l.pos] != '\n' && l.data[l.pos] != '\r' {
+				l.pos++
+			}
+		default:
+			return
+		}
+	}
+}
+
+// token reads a run of regular characters
+func (l *pdfLexer) token() string {
+	start := l.pos
+	for l.pos < len(l.data) && !isPDFSpace(l.data[l.pos]) && !isPDFDelimiter(l.data[l.pos]) {
+		l.pos++
+	}
+	if l.pos == len(l.data) {
+		l.eof = true
+	}
+	return string(l.data[start:l.pos])
+}
+
+// keyword reads a keyword or number
+func (l *pdfLexer) keyword() string {
+	l.skipSpace()
+	return l.token()
+}
+
+// truncatedOr returns errTruncated if the data ran out, or a malformed PDF
+// error with the message
+func (l *pdfLexer) truncatedOr(msg string) error {
+	if l.eof && l.more {
+		return errTruncated
+	}
+	return fmt.Errorf("%w: %s", ErrMalformedPDF, msg)
+}
+
+// value reads a value and returns it in PDF syntax
+func (l *pdfLexer) value() (string, error) {
+	l.skipSpace()
+	start := l.pos
+	if err := l.skipValue(0); err != nil {
+		return "", err
+	}
+	return string(l.data[start:l.pos]), nil
+}
+
+// maxPDFNesting bounds how deeply arrays and dictionaries may nest
+const maxPDFNesting = 100
+
+func (l *pdfLexer) skipValue(depth int) error {
+	if depth > maxPDFNesting {
+		return fmt.Errorf("%w: values nested too deeply", ErrMalformedPDF)
+	}
+	l.skipSpace()
+	if l.pos >= len(l.data) {
+		l.eof = true
+		return l.truncatedOr("expected a value")
+	}
+
+	switch c := l.data[l.pos]; {
+	case c == '/':
+		l.pos++
+		l.token()
+	case c == '(':
+		nesting := 0
+		for l.pos++; ; l.pos++ {
+			if l.pos >= len(l.data) {
+				l.eof = true
+				return l.truncatedOr("unterminated string")
+			}
+			switch l.data[l.pos] {
+			case '\\':
+				l.pos++
+			case '(':
+				nesting++
+			case ')':
+				if nesting == 0 {
+					l.pos++
+					return nil
+				}
+				nesting--
+			}
+		}
+	case bytes.HasPrefix(l.data[l.pos:], []byte("<<")):
+		l.pos += 2
+		for {
+			l.skipSpace()
+			switch {
+			case bytes.HasPrefix(l.data[l.pos:], []byte(">>")):
+				l.pos += 2
+				return nil
+			case l.pos >= len(l.data)-1:
+				l.eof = true
+				return l.truncatedOr("unterminated dictionary")
+			}
+			if err := l.skipValue(depth + 1); err != nil {
+				return err
+			}
+		}
+	case c == '<':
+		end := bytes.IndexByte(l.data[l.pos:], '>')
+		if end < 0 {
+			l.eof = true
+			return l.truncatedOr("unterminated hex string")
+		}
+		l.pos += end + 1
+	case c == '[':
+		l.pos++
+		for {
+			l.skipSpace()
+			switch {
+			case l.pos >= len(l.data):
+				l.eof = true
+				return l.truncatedOr("unterminated array")
+			case l.data[l.pos] == ']':
+				l.pos++
+				return nil
+			}
+			if err := l.skipValue(depth + 1); err != nil {
+				return err
+			}
+		}
+	case isPDFDelimiter(c):
+		return fmt.Errorf("%w: unexpected %q", ErrMalformedPDF, c)
+	default:
+		token := l.token()
+		if _, err := strconv.Atoi(token); err == nil {
+			// Two integers followed by R make a reference
+			mark := l.pos
+			gen, r := l.keyword(), l.keyword()
+			if _, err := strconv.Atoi(gen); err != nil || r != "R" {
+				l.pos = mark
+			}
+		}
+		if l.eof && l.more {
+			return errTruncated
+		}
+	}
+	return nil
+}
@@ -0,0 +1,282 @@
+package wclist
+
+import (
+	"bytes"
+	"compress/zlib"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/ledongthuc/pdf"
+)
+
+// objectStreamPDF builds a one page PDF whose page tree is compressed in an
+// object stream, indexed by a cross-reference stream with PNG prediction
+func objectStreamPDF(t *testing.T) []byte {
+	t.Helper()
+	compress := func(data []byte) []byte {
+		var buf bytes.Buffer
+		zw := zlib.NewWriter(&buf)
+		zw.Write(data)
+		zw.Close()
+		return buf.Bytes()
+	}
+
+	compressed := []string{
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		"<< /Type /Pages /Kids [3 0 R] /Count 1 /Resources << /Font << /F1 << /Type /Font /Subtype /Type1 /BaseFont /Helvetica >> >> >> >>",
+		"<< /Type /Page /Parent 2 0 R /MediaBox [0 0 612 792] /Contents 5 0 R >>",
+	}
+	var header, body bytes.Buffer
+	for i, object := range compressed {
+		fmt.Fprintf(&header, "%d %d ", i+1, body.Len())
+		body.WriteString(object + "\n")
+	}
+	objstm := compress(append(header.Bytes(), body.Bytes()...))
+
+	var buf bytes.Buffer
+	buf.WriteString("%PDF-1.5\n")
+	offsets := make(map[int]int)
+	offsets[4] = buf.Len()
+	fmt.Fprintf(&buf, "4 0 obj\n<< /Type /ObjStm /N 3 /First %d /Filter /FlateDecode /Length %d >>\nstream\n%s\nendstream\nendobj\n", header.Len(), len(objstm), objstm)
+	content := textPage("WARDEN'S COURT KALGOORLIE")
+	offsets[5] = buf.Len()
+	fmt.Fprintf(&buf, "5 0 obj\n<< /Length %d >>\nstream\n%s\nendstream\nendobj\n", len(content), content)
+
+	// Rows of type, offset or object stream, and generation or index, each
+	// predicted from the row above
+	xref := buf.Len()
+	rows := [][]byte{{0, 0, 0, 0, 0, 0xff, 0xff}}
+	for num := 1; num <= 6; num++ {
+		row := make([]byte, 7)
+		switch {
+		case num <= 3:
+			row[0] = 2
+			binary.BigEndian.PutUint32(row[1:], 4)
+			binary.BigEndian.PutUint16(row[5:], uint16(num-1))
+		case num == 6:
+			row[0] = 1
+			binary.BigEndian.PutUint32(row[1:], uint32(xref))
+		default:
+			row[0] = 1
+			binary.BigEndian.PutUint32(row[1:], uint32(offsets[num]))
+		}
+		rows = append(rows, row)
+	}
+	var predicted bytes.Buffer
+	prev := make([]byte, 7)
+	for _, row := range rows {
+		predicted.WriteByte(2)
+		for i := range row {
+			predicted.WriteByte(row[i] - prev[i])
+		}
+		prev = row
+	}
+	data := compress(predicted.Bytes())
+	fmt.Fprintf(&buf, "6 0 obj\n<< /Type /XRef /Size 7 /W [1 4 2] /Root 1 0 R /Filter /FlateDecode /DecodeParms << /Predictor 12 /Columns 7 >> /Length %d >>\nstream\n%s\nendstream\nendobj\n", len(data), data)
+	fmt.Fprintf(&buf, "startxref\n%d\n%%%%EOF\n", xref)
+	return buf.Bytes()
+}
+
+func TestPDFObjects(t *testing.T) {
+	t.Run("Cross-reference table", func(t *testing.T) {
+		data, err := os.ReadFile("../test/test.pdf")
+		if err != nil {
+			t.Fatalf("Failed to read test PDF: %v", err)
+		}
+		objects, err := newPDFObjects(context.Background(), bytes.NewReader(data), int64(len(data)))
+		if err != nil {
+			t.Fatalf("Failed to read objects: %v", err)
+		}
+		_, pages, err := objects.pages()
+		if err != nil {
+			t.Fatalf("Failed to read page tree: %v", err)
+		}
+
+		reader, err := pdf.NewReader(bytes.NewReader(data), int64(len(data)))
+		if err != nil {
+			t.Fatalf("Failed to open test PDF: %v", err)
+		}
+		if len(pages) != reader.NumPage() {
+			t.Fatalf("Expected %d pages, got %d", reader.NumPage(), len(pages))
+		}
+		for i, page := range pages {
+			object, err := objects.object(page.ref)
+			if err != nil {
+				t.Fatalf("Failed to read page %d: %v", i+1, err)
+			}
+			dict, err := parsePDFDict(object.value)
+			if err != nil || dictName(dict, "Type") != "Page" {
+				t.Fatalf("Expected page %d to be a page, got %.80q (%v)", i+1, object.value, err)
+			}
+		}
+	})
+
+	t.Run("Object and cross-reference streams", func(t *testing.T) {
+		data := objectStreamPDF(t)
+		objects, err := newPDFObjects(context.Background(), bytes.NewReader(data), int64(len(data)))
+		if err != nil {
+			t.Fatalf("Failed to read objects: %v", err)
+		}
+		root, pages, err := objects.pages()
+		if err != nil {
+			t.Fatalf("Failed to read page tree: %v", err)
+		}
+		if root != (pdfRef{2, 0}) || len(pages) != 1 || pages[0].ref != (pdfRef{3, 0}) {
+			t.Fatalf("Expected page 3 0 R under 2 0 R, got %v under %v", pages, root)
+		}
+		// The page inherits its resources from the page tree
+		resources, err := parsePDFDict(pages[0].resources)
+		if err != nil {
+			t.Fatalf("Failed to read inherited resources: %v", err)
+		}
+		if _, ok := dictValue(resources, "Font"); !ok {
+			t.Fatalf("Expected inherited fonts, got %v", resources)
+		}
+
+		object, err := objects.object(pdfRef{num: 5})
+		if err != nil {
+			t.Fatalf("Failed to read content stream: %v", err)
+		}
+		content := textPage("WARDEN'S COURT KALGOORLIE")
+		stream, err := objects.streamData(object.data, int64(len(content)), 0)
+		if err != nil || string(stream) != content {
+			t.Fatalf("Expected the content stream, got %q (%v)", stream, err)
+		}
+		if _, err := objects.streamData(object.data, int64(len(content)), 10); !errors.Is(err, ErrTooLarge) {
+			t.Fatalf("Expected ErrTooLarge over the limit, got %v", err)
+		}
+		if _, err := objects.streamData(object.data, int64(len(data)), 0); !errors.Is(err, ErrMalformedPDF) {
+			t.Fatalf("Expected ErrMalformedPDF past the end of the file, got %v", err)
+		}
+	})
+
+	// xrefStreamPDF ends the objects in body with a cross-reference stream
+	// of one byte fields, given its own offset as the last row
+	xrefStreamPDF := func(body string, dict string, rows ...[]byte) []byte {
+		var buf bytes.Buffer
+		buf.WriteString("%PDF-1.5\n" + body)
+		xref := buf.Len()
+		data := bytes.Join(append(rows, []byte{1, byte(xref), 0}), nil)
+		fmt.Fprintf(&buf, "%d 0 obj\n<< /Type /XRef %s /Length %d >>\nstream\n%s\nendstream\nendobj\n", len(rows), dict, len(data), data)
+		fmt.Fprintf(&buf, "startxref\n%d\n%%%%EOF\n", xref)
+		return buf.Bytes()
+	}
+
+	t.Run("Object stream inside itself", func(t *testing.T) {
+		data := xrefStreamPDF("1 0 obj\n<< /Type /ObjStm /N 1 /First 4 /Length 8 >>\nstream\n1 0 null\nendstream\nendobj\n",
+			"/Size 3 /W [1 1 1] /Root 1 0 R", []byte{0, 0, 0}, []byte{2, 1, 0})
+		objects, err := newPDFObjects(context.Background(), bytes.NewReader(data), int64(len(data)))
+		if err != nil {
+			t.Fatalf("Failed to read objects: %v", err)
+		}
+		if _, err := objects.object(pdfRef{num: 1}); !errors.Is(err, ErrMalformedPDF) {
+			t.Fatalf("Expected ErrMalformedPDF, got %v", err)
+		}
+
+		// The PDF reader would follow the stream until it ran out of stack
+		if err := objects.checkObjectStreams(context.Background()); !errors.Is(err, ErrMalformedPDF) {
+			t.Fatalf("Expected the check to find ErrMalformedPDF, got %v", err)
+		}
+		cl := NewCauseList("", "", time.Time{})
+		if err := cl.ReadCauseList(bytes.NewReader(data), int64(len(data))); !errors.Is(err, ErrMalformedPDF) {
+			t.Fatalf("Expected reading to give ErrMalformedPDF, got %v", err)
+		}
+		if err := WriteHighlightedPDF(&bytes.Buffer{}, bytes.NewReader(data), int64(len(data)), nil); !errors.Is(err, ErrMalformedPDF) {
+			t.Fatalf("Expected highlighting to give ErrMalformedPDF, got %v", err)
+		}
+	})
+
+	t.Run("Object stream whose length is inside itself", func(t *testing.T) {
+		data := xrefStreamPDF("1 0 obj\n<< /Type /ObjStm /N 1 /First 4 /Length 3 0 R >>\nstream\n3 0 8\nendstream\nendobj\n",
+			"/Size 4 /W [1 1 1] /Index [0 2 3 1 2 1] /Root 1 0 R", []byte{0, 0, 0}, []byte{1, 9, 0}, []byte{2, 1, 0})
+		objects, err := newPDFObjects(context.Background(), bytes.NewReader(data), int64(len(data)))
+		if err != nil {
+			t.Fatalf("Failed to read objects: %v", err)
+		}
+		if _, err := objects.object(pdfRef{num: 3}); !errors.Is(err, ErrMalformedPDF) {
+			t.Fatalf("Expected ErrMalformedPDF, got %v", err)
+		}
+		if err := objects.checkObjectStreams(context.Background()); !errors.Is(err, ErrMalformedPDF) {
+			t.Fatalf("Expected the check to find ErrMalformedPDF, got %v", err)
+		}
+	})
+
+	t.Run("Object streams checked", func(t *testing.T) {
+		data := objectStreamPDF(t)
+		objects, err := newPDFObjects(context.Background(), bytes.NewReader(data), int64(len(data)))
+		if err != nil {
+			t.Fatalf("Failed to read objects: %v", err)
+		}
+		if err := objects.checkObjectStreams(context.Background()); err != nil {
+			t.Fatalf("Expected a well formed object stream to pass, got %v", err)
+		}
+	})
+
+	t.Run("Cross-reference stream entries", func(t *testing.T) {
+		for _, dict := range []string{"/Size 2000000000 /W [0 0 0]", "/Size 2000000000 /W [1 1 1]", "/Index [0 1 5 -1] /W [1 1 1]"} {
+			data := xrefStreamPDF("", dict, []byte{0, 0, 0})
+			if _, err := newPDFObjects(context.Background(), bytes.NewReader(data), int64(len(data))); !errors.Is(err, ErrMalformedPDF) {
+				t.Fatalf("Expected ErrMalformedPDF for %s, got %v", dict, err)
+			}
+		}
+
+		data := xrefStreamPDF("", "/Size 2 /W [1 1 1]", []byte{0, 0, 0})
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		if _, err := newPDFObjects(ctx, bytes.NewReader(data), int64(len(data))); !errors.Is(err, context.Canceled) {
+			t.Fatalf("Expected context.Canceled, got %v", err)
+		}
+	})
+
+	t.Run("Truncated", func(t *testing.T) {
+		data := minimalPDF(textPage("OBJECTIONS"))
+		data = bytes.Replace(data, []byte("trailer\n<<"), []byte("trailer\n[["), 1)
+		if _, err := newPDFObjects(context.Background(), bytes.NewReader(data), int64(len(data))); !errors.Is(err, ErrMalformedPDF) {
+			t.Fatalf("Expected ErrMalformedPDF, got %v", err)
+		}
+	})
+}
+
+func TestParsePDFDict(t *testing.T) {
+	dict, err := parsePDFDict(`<</Type/Page % comment
+		/Contents [4 0 R 5 0 R] /T (a (nested\) string)) /ID <0a0b> /Parent 2 0 R
+		/Sub << /N 1.5 /K -1 >> /A#20B true >>`)
+	if err != nil {
+		t.Fatalf("Failed to parse dictionary: %v", err)
+	}
+	expected := []dictEntry{
+		{"Type", "/Page"},
+		{"Contents", "[4 0 R 5 0 R]"},
+		{"T", `(a (nested\) string))`},
+		{"ID", "<0a0b>"},
+		{"Parent", "2 0 R"},
+		{"Sub", "<< /N 1.5 /K -1 >>"},
+		{"A#20B", "true"},
+	}
+	if !reflect.DeepEqual(dict, expected) {
+		t.Fatalf("Expected %v, got %v", expected, dict)
+	}
+
+	elements, err := parsePDFArray(dict[1].value)
+	if err != nil || !reflect.DeepEqual(elements, []string{"4 0 R", "5 0 R"}) {
+		t.Fatalf("Expected two references, got %v (%v)", elements, err)
+	}
+	if ref, err := parseRef(dict[4].value); err != nil || ref != (pdfRef{2, 0}) {
+		t.Fatalf("Expected 2 0 R, got %v (%v)", ref, err)
+	}
+	if pdfNameDecode("A#20B") != "A B" {
+		t.Fatalf("Expected #20 to decode to a space")
+	}
+
+	for _, s := range []string{"<< /A (unterminated >>", "<< /A [1 2 >>", "[1 2]", "<< 1 2 >>"} {
+		if _, err := parsePDFDict(s); !errors.Is(err, ErrMalformedPDF) {
+			t.Fatalf("Expected ErrMalformedPDF for %q, got %v", s, err)
+		}
+	}
+}
@@ -0,0 +1,274 @@
+package wclist
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+
+	"github.com/ledongthuc/pdf"
+)
+
+// pdfRef is a reference to an indirect PDF object
+type pdfRef struct {
+	num, gen int
+}
+
+func (r pdfRef) String() string { return fmt.Sprintf("%d %d R", r.num, r.gen) }
+
+// pdfUpdate appends objects to a PDF as an incremental update, leaving the
+// original bytes untouched. New objects are numbered from the original's
+// /Size, and objects written with an existing number replace the original.
+//
+// The PDF reader doesn't expose object numbers, so the objects rewritten
+// are read in their own syntax through pdfObjects.
+type pdfUpdate struct {
+	reader  *pdf.Reader
+	objects *pdfObjects
+	size    int64 // Length of the original file
+	prev    int64 // Offset of the original cross-reference section
+	stream  bool  // Whether the original uses a cross-reference stream
+	next    int   // Next free object number
+	body    bytes.Buffer
+	offsets map[int]int64
+}
+
+// newPDFUpdate opens a PDF for an incremental update. Encrypted PDFs are
+// rejected, since new strings and streams would have to be encrypted.
+func newPDFUpdate(ctx context.Context, r io.ReaderAt, size int64) (update *pdfUpdate, err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			update, err = nil, fmt.Errorf("%w: %v", ErrMalformedPDF, p)
+		}
+	}()
+
+	objects, err := checkedPDFObjects(ctx, r, size)
+	if err != nil {
+		return nil, err
+	}
+	reader, err := pdf.NewReader(r, size)
+	switch {
+	case errors.Is(err, pdf.ErrInvalidPassword), unsupportedEncryption(err):
+		return nil, fmt.Errorf("%w: can't update an encrypted PDF", ErrEncryptedPDF)
+	case err != nil:
+		return nil, fmt.Errorf("%w: %w", ErrMalformedPDF, err)
+	}
+	trailer := reader.Trailer()
+	if !trailer.Key("Encrypt").IsNull() {
+		return nil, fmt.Errorf("%w: can't update an encrypted PDF", ErrEncryptedPDF)
+	}
+
+	prev, err := lastStartXref(r, size)
+	if err != nil {
+		return nil, err
+	}
+	head := make([]byte, 4)
+	if _, err := r.ReadAt(head, prev); err != nil {
+		return nil, fmt.Errorf("%w: reading cross-reference section: %w", ErrMalformedPDF, err)
+	}
+
+	update = &pdfUpdate{
+		reader:  reader,
+		objects: objects,
+		size:    size,
+		prev:    prev,
+		stream:  string(head) != "xref",
+		next:    int(trailer.Key("Size").Int64()),
+		offsets: make(map[int]int64),
+	}
+	// The update must start on a new line
+	update.body.WriteString("\n")
+	return update, nil
+}
+
+var startXrefPattern = regexp.MustCompile(`startxref\s+(\d+)`)
+
+// lastStartXref reads the offset of the last cross-reference section from
+// the end of a PDF
+func lastStartXref(r io.ReaderAt, size int64) (int64, error) {
+	tail := make([]byte, min(size, 2048))
+	if _, err := r.ReadAt(tail, size-int64(len(tail))); err != nil && err != io.EOF {
+		return 0, fmt.Errorf("%w: %w", ErrMalformedPDF, err)
+	}
+	matches := startXrefPattern.FindAllSubmatch(tail, -1)
+	if len(matches) == 0 {
+		return 0, fmt.Errorf("%w: missing startxref", ErrMalformedPDF)
+	}
+	offset, err := strconv.ParseInt(string(matches[len(matches)-1][1]), 10, 64)
+	if err != nil || offset >= size {
+		return 0, fmt.Errorf("%w: invalid startxref", ErrMalformedPDF)
+	}
+	return offset, nil
+}
+
+// allocate reserves the number of a new object
+func (u *pdfUpdate) allocate() pdfRef {
+	u.next++
+	return pdfRef{num: u.next - 1}
+}
+
+// writeObject writes an object with the given number
+func (u *pdfUpdate) writeObject(ref pdfRef, object string) {
+	u.offsets[ref.num] = u.size + int64(u.body.Len())
+	fmt.Fprintf(&u.body, "%d %d obj\n%s\nendobj\n", ref.num, ref.gen, object)
+}
+
+// writeStream writes a stream object with the given dictionary entries
+func (u *pdfUpdate) writeStream(ref pdfRef, entries string, data []byte) {
+	if entries != "" {
+		entries += " "
+	}
+	u.writeObject(ref, fmt.Sprintf("<< %s/Length %d >>\nstream\n%s\nendstream", entries, len(data), data))
+}
+
+// finish writes the original PDF followed by the update, with a
+// cross-reference section of the same kind as the original's
+func (u *pdfUpdate) finish(w io.Writer, original io.ReaderAt) error {
+	trailer := u.trailerEntries()
+
+	if u.stream {
+		ref := u.allocate()
+		u.offsets[ref.num] = u.size + int64(u.body.Len())
+		data, index := u.xrefStreamData()
+		fmt.Fprintf(&u.body, "%d 0 obj\n<< /Type /XRef %s /Size %d /Prev %d /W [1 4 2] /Index [%s] /Length %d >>\nstream\n",
+			ref.num, trailer, u.next, u.prev, index, len(data))
+		u.body.Write(data)
+		u.body.WriteString("\nendstream\nendobj\n")
+		fmt.Fprintf(&u.body, "startxref\n%d\n%%%%EOF\n", u.offsets[ref.num])
+	} else {
+		start := u.size + int64(u.body.Len())
+		u.body.WriteString("xref\n")
+		for _, run := range u.runs() {
+			fmt.Fprintf(&u.body, "%d %d\n", run[0], len(run))
+			for _, num := range run {
+				fmt.Fprintf(&u.body, "%010d 00000 n \n", u.offsets[num])
+			}
+		}
+		fmt.Fprintf(&u.body, "trailer\n<< %s /Size %d /Prev %d >>\nstartxref\n%d\n%%%%EOF\n", trailer, u.next, u.prev, start)
+	}
+
+	if _, err := io.Copy(w, io.NewSectionReader(original, 0, u.size)); err != nil {
+		return err
+	}
+	_, err := w.Write(u.body.Bytes())
+	return err
+}
+
+// runs groups the written object numbers into consecutive runs
+func (u *pdfUpdate) runs() [][]int {
+	nums := make([]int, 0, len(u.offsets))
+	for num := range u.offsets {
+		nums = append(nums, num)
+	}
+	slices.Sort(nums)
+
+	var runs [][]int
+	for _, num := range nums {
+		if n := len(runs); n > 0 && runs[n-1][len(runs[n-1])-1] == num-1 {
+			runs[n-1] = append(runs[n-1], num)
+			continue
+		}
+		runs = append(runs, []int{num})
+	}
+	return runs
+}
+
+// xrefStreamData encodes the cross-reference entries of the written
+// objects, returning the data and the /Index of the stream
+func (u *pdfUpdate) xrefStreamData() ([]byte, string) {
+	var data bytes.Buffer
+	var index []string
+	for _, run := range u.runs() {
+		index = append(index, fmt.Sprintf("%d %d", run[0], len(run)))
+		for _, num := range run {
+			data.WriteByte(1)
+			binary.Write(&data, binary.BigEndian, uint32(u.offsets[num]))
+			binary.Write(&data, binary.BigEndian, uint16(0))
+		}
+	}
+	return data.Bytes(), strings.Join(index, " ")
+}
+
+// trailerEntries returns the entries of the original trailer carried into
+// the update's trailer
+func (u *pdfUpdate) trailerEntries() string {
+	var out []string
+	for _, entry := range u.objects.trailer {
+		switch entry.key {
+		case "Root", "Info", "ID":
+			out = append(out, "/"+entry.key+" "+entry.value)
+		}
+	}
+	return strings.Join(out, " ")
+}
+
+// pageRefs returns the reference of each page in order, along with the
+// reference of the root of the page tree
+func (u *pdfUpdate) pageRefs() (root pdfRef, pages []pdfRef, err error) {
+	root, nodes, err := u.objects.pages()
+	if err != nil {
+		return pdfRef{}, nil, err
+	}
+	for _, node := range nodes {
+		pages = append(pages, node.ref)
+	}
+	return root, pages, nil
+}
+
+// objectDict reads the dictionary of an object of the original
+func (u *pdfUpdate) objectDict(ref pdfRef) ([]dictEntry, error) {
+	object, err := u.objects.object(ref)
+	if err != nil {
+		return nil, err
+	}
+	return parsePDFDict(object.value)
+}
+
+// dictEntry is one entry of a dictionary, with its value in PDF syntax
+type dictEntry struct {
+	key, value string
+}
+
+// formatDict writes dictionary entries in PDF syntax
+func formatDict(entries []dictEntry) string {
+	var b strings.Builder
+	b.WriteString("<<")
+	for _, entry := range entries {
+		fmt.Fprintf(&b, " /%s %s", entry.key, entry.value)
+	}
+	b.WriteString(" >>")
+	return b.String()
+}
+
+// setDictEntry replaces the value of key, or adds it
+func setDictEntry(entries []dictEntry, key, value string) []dictEntry {
+	for i := range entries {
+		if entries[i].key == key {
+			entries[i].value = value
+			return entries
+		}
+	}
+	return append(entries, dictEntry{key, value})
+}
+
+// pdfHexString writes text as a PDF hex string
+func pdfHexString(text string) string {
+	for _, r := range text {
+		if r > 0x7e {
+			encoded := []byte{0xfe, 0xff}
+			for _, unit := range utf16.Encode([]rune(text)) {
+				encoded = binary.BigEndian.AppendUint16(encoded, unit)
+			}
+			return "<" + hex.EncodeToString(encoded) + ">"
+		}
+	}
+	return "<" + hex.EncodeToString([]byte(text)) + ">"
+}
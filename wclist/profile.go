@@ -0,0 +1,366 @@
+package wclist
+
+import (
+	"embed"
+	"fmt"
+	"os"
+	"path"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Profile describes the layout of one registry's cause lists: how to
+// recognise them, which pages and lines to ignore, and the sections and
+// columns of their tables. Profiles are written in YAML or JSON.
+type Profile struct {
+	Name string `json:"name" yaml:"name"`
+
+	// Detect holds phrases that identify documents in this layout. When no
+	// profile is chosen, the one with the most phrases in the opening text
+	// of a document is used.
+	Detect []string `json:"detect,omitempty" yaml:"detect"`
+
+	CoverPages      int              `json:"cover_pages" yaml:"cover_pages"`                     // Leading PDF pages that hold no items
+	HeaderKeywords  []string         `json:"header_keywords" yaml:"header_keywords"`             // Lines containing these are headers
+	NoisePatterns   []string         `json:"noise_patterns,omitempty" yaml:"noise_patterns"`     // Lines matching these are ignored
+	TenementPattern string           `json:"tenement_pattern,omitempty" yaml:"tenement_pattern"` // Finds the tenement in PDF text
+	Sections        []SectionProfile `json:"sections" yaml:"sections"`
+
+	noise    []*regexp.Regexp
+	tenement *regexp.Regexp
+	headers  []string // Normalised HeaderKeywords
+}
+
+// SectionProfile describes one section of a cause list
+type SectionProfile struct {
+	Type     string   `json:"type" yaml:"type"`                   // Matter type of a registered SectionParser, e.g. "objection"
+	Headings []string `json:"headings,omitempty" yaml:"headings"` // Words in a heading that start the section
+
+	// Columns lists the cells of a table row in order, using the column
+	// names of the section's parser. Columns ending in "?" may be missing
+	// from the end of a row.
+	Columns []string `json:"columns,omitempty" yaml:"columns"`
+}
+
+// defaultTenementPattern matches tenements like "E 15/2082" and "L 28/100"
+const defaultTenementPattern = `\b([A-Z]+\s+\d+/\d+)\b`
+
+//go:embed profiles/*.yaml
+var profileFiles embed.FS
+
+var (
+	profilesMu sync.RWMutex
+	profiles   = loadEmbeddedProfiles()
+)
+
+// loadEmbeddedProfiles parses the profiles built into the package
+func loadEmbeddedProfiles() []*Profile {
+	entries, err := profileFiles.ReadDir("profiles")
+	if err != nil {
+		panic(err)
+	}
+
+	var loaded []*Profile
+	for _, entry := range entries {
+		data, err := profileFiles.ReadFile(path.Join("profiles", entry.Name()))
+		if err != nil {
+			panic(err)
+		}
+		p, err := ParseProfile(data)
+		if err != nil {
+			panic(fmt.Sprintf("wclist: built-in profile %s: %v", entry.Name(), err))
+		}
+		loaded = append(loaded, p)
+	}
+	return loaded
+}
+
+// ParseProfile reads a profile from YAML or JSON and checks that it is valid
+func ParseProfile(data []byte) (*Profile, error) {
+	var p Profile
+	if err := yaml.Unmarshal(data, &p); err != nil {
+		return nil, fmt.Errorf("parsing profile: %w", err)
+	}
+	if err := p.compile(); err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+// LoadProfile reads a profile from a YAML or JSON file
+func LoadProfile(filename string) (*Profile, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	return ParseProfile(data)
+}
+
+// RegisterProfile adds a profile for auto-detection, replacing any
+// registered profile with the same name
+func RegisterProfile(p *Profile) error {
+	compiled, err := p.compiled()
+	if err != nil {
+		return err
+	}
+
+	profilesMu.Lock()
+	defer profilesMu.Unlock()
+	for i, registered := range profiles {
+		if registered.Name == compiled.Name {
+			profiles[i] = compiled
+			return nil
+		}
+	}
+	profiles = append(profiles, compiled)
+	return nil
+}
+
+// Profiles returns the registered profiles, built-in profiles first
+func Profiles() []*Profile {
+	profilesMu.RLock()
+	defer profilesMu.RUnlock()
+	return slices.Clone(profiles)
+}
+
+// ProfileByName returns the registered profile with the given name, or nil
+// if there isn't one
+func ProfileByName(name string) *Profile {
+	profilesMu.RLock()
+	defer profilesMu.RUnlock()
+	for _, p := range profiles {
+		if p.Name == name {
+			return p
+		}
+	}
+	return nil
+}
+
+// DefaultProfile returns the built-in profile for the Warden's Courts of
+// Western Australia
+func DefaultProfile() *Profile {
+	profilesMu.RLock()
+	defer profilesMu.RUnlock()
+	return profiles[0]
+}
+
+// DetectProfile chooses the registered profile with the most detection
+// phrases in the text, falling back to the default profile
+func DetectProfile(text string) *Profile {
+	text = normalizeProfileText(text)
+
+	profilesMu.RLock()
+	defer profilesMu.RUnlock()
+
+	best, bestScore := profiles[0], 0
+	for _, p := range profiles {
+		score := 0
+		for _, phrase := range p.Detect {
+			if strings.Contains(text, normalizeProfileText(phrase)) {
+				score++
+			}
+		}
+		if score > bestScore {
+			best, bestScore = p, score
+		}
+	}
+	return best
+}
+
+// normalizeProfileText lower cases text and straightens curly apostrophes,
+// which registries use inconsistently in names like "Warden's Court"
+func normalizeProfileText(text string) string {
+	return strings.ReplaceAll(strings.ToLower(text), "’", "'")
+}
+
+// profile returns the profile to read a document with: the one in the
+// options if set, otherwise the one detected from the document's opening text
+func (o ReadOptions) profile(text func() string) (*Profile, error) {
+	if o.Profile != nil {
+		return o.Profile.compiled()
+	}
+	return DetectProfile(text()), nil
+}
+
+// compiled returns the profile with its patterns compiled, copying it if
+// needed so that profiles shared between reads are never modified
+func (p *Profile) compiled() (*Profile, error) {
+	if p.tenement != nil {
+		return p, nil
+	}
+	c := *p
+	if err := c.compile(); err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+// compile checks the profile and compiles its patterns
+func (p *Profile) compile() error {
+	if p.Name == "" {
+		return fmt.Errorf("profile has no name")
+	}
+	if p.CoverPages < 0 {
+		return fmt.Errorf("profile %s: cover_pages can't be negative", p.Name)
+	}
+	if len(p.Sections) == 0 {
+		return fmt.Errorf("profile %s has no sections", p.Name)
+	}
+
+	// Sections without their own headings or columns take the parser's
+	// defaults
+	p.Sections = slices.Clone(p.Sections)
+	for i, section := range p.Sections {
+		parser, ok := lookupSectionParser(section.Type)
+		if !ok {
+			return fmt.Errorf("profile %s: unknown section type %q", p.Name, section.Type)
+		}
+		if len(section.Headings) == 0 {
+			p.Sections[i].Headings = parser.Headings()
+		}
+		if len(section.Columns) == 0 {
+			p.Sections[i].Columns = parser.Columns()
+		}
+
+		columns := p.Sections[i].Columns
+		if !slices.Contains(columns, "matter_number") {
+			return fmt.Errorf("profile %s: %s section has no matter_number column", p.Name, section.Type)
+		}
+		for _, column := range columns {
+			if !slices.ContainsFunc(parser.Columns(), func(known string) bool {
+				return strings.TrimSuffix(known, "?") == strings.TrimSuffix(column, "?")
+			}) {
+				return fmt.Errorf("profile %s: %s section has unknown column %q", p.Name, section.Type, column)
+			}
+		}
+	}
+
+	p.headers = nil
+	for _, keyword := range p.HeaderKeywords {
+		p.headers = append(p.headers, normalizeProfileText(keyword))
+	}
+
+	p.noise = nil
+	for _, pattern := range p.NoisePatterns {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return fmt.Errorf("profile %s: noise pattern: %w", p.Name, err)
+		}
+		p.noise = append(p.noise, re)
+	}
+
+	tenementPattern := p.TenementPattern
+	if tenementPattern == "" {
+		tenementPattern = defaultTenementPattern
+	}
+	tenement, err := regexp.Compile(tenementPattern)
+	if err != nil {
+		return fmt.Errorf("profile %s: tenement pattern: %w", p.Name, err)
+	}
+	if tenement.NumSubexp() == 0 {
+		return fmt.Errorf("profile %s: tenement pattern needs a group around the tenement", p.Name)
+	}
+	p.tenement = tenement
+
+	return nil
+}
+
+// isNoise reports whether a line should be ignored
+func (p *Profile) isNoise(line string) bool {
+	for _, re := range p.noise {
+		if re.MatchString(line) {
+			return true
+		}
+	}
+	return false
+}
+
+// sections returns the profile's sections followed by a section for each
+// registered matter type the profile doesn't list, using the parser's
+// headings and columns
+func (p *Profile) sections() []SectionProfile {
+	sections := p.Sections
+	for _, parser := range SectionParsers() {
+		if !slices.ContainsFunc(p.Sections, func(s SectionProfile) bool { return s.Type == parser.Type() }) {
+			sections = append(slices.Clip(sections), SectionProfile{
+				Type:     parser.Type(),
+				Headings: parser.Headings(),
+				Columns:  parser.Columns(),
+			})
+		}
+	}
+	return sections
+}
+
+// isHeaderLine checks if a line is a header or section title, using the
+// profile's header keywords and those of every registered matter type
+func (p *Profile) isHeaderLine(line string) bool {
+	line = normalizeProfileText(line)
+	for _, keywords := range [][]string{p.headers, headerKeywords()} {
+		for _, keyword := range keywords {
+			if strings.Contains(line, keyword) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// detectSectionType determines what type of matters are being listed from
+// the first section whose headings appear in the text
+func (p *Profile) detectSectionType(text string) string {
+	text = normalizeProfileText(text)
+	for _, section := range p.sections() {
+		for _, heading := range section.Headings {
+			if strings.Contains(text, normalizeProfileText(heading)) {
+				return section.Type
+			}
+		}
+	}
+	return "unknown"
+}
+
+// section returns the section of the given type, or the first section when
+// the type isn't in the profile
+func (p *Profile) section(sectionType string) SectionProfile {
+	for _, section := range p.sections() {
+		if section.Type == sectionType {
+			return section
+		}
+	}
+	return p.Sections[0]
+}
+
+// cells names the cells of a table row by the section's columns. Missing
+// optional columns are left empty.
+func (s SectionProfile) cells(fields []string) (map[string]string, error) {
+	required := 0
+	for i, column := range s.Columns {
+		if !strings.HasSuffix(column, "?") {
+			required = i + 1
+		}
+	}
+
+	if len(fields) < required {
+		raw := strings.Join(fields, " | ")
+		if required < len(s.Columns) {
+			return nil, rowError(s.Type, raw, "expected at least %d cells, got %d", required, len(fields))
+		}
+		return nil, rowError(s.Type, raw, "expected %d cells, got %d", required, len(fields))
+	}
+
+	cells := make(map[string]string, len(s.Columns))
+	for i, column := range s.Columns {
+		cells[strings.TrimSuffix(column, "?")] = getFieldOrEmpty(fields, i)
+	}
+	return cells, nil
+}
+
+// firstPage returns the first PDF page that holds items
+func (p *Profile) firstPage() int {
+	return p.CoverPages + 1
+}
@@ -0,0 +1,130 @@
+package wclist
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+// ntProfile describes a made-up layout that lists the tenement before the
+// objection number and the applicant before the objector
+const ntProfile = `{
+	"name": "test-nt-mining-court",
+	"detect": ["mining court of the northern territory"],
+	"cover_pages": 0,
+	"header_keywords": ["matter", "tenement"],
+	"noise_patterns": ["^Page \\d+ of \\d+$"],
+	"sections": [
+		{"type": "objection", "headings": ["objection"],
+		 "columns": ["matter_number", "tenement", "objection_number", "applicant", "objector", "comments?"]}
+	]
+}`
+
+const ntCauseList = `<html><body>
+<h1>Mining Court of the Northern Territory</h1>
+<h2>Objections</h2>
+<table>
+<tr><th>Matter</th><th>Tenement</th><th>Objection</th><th>Applicant</th><th>Objector</th></tr>
+<tr><td>7</td><td>EL 32/101</td><td>900123</td><td>ARAFURA RESOURCES LTD</td><td>TOP END PROSPECTING</td></tr>
+</table>
+</body></html>`
+
+func TestProfiles(t *testing.T) {
+	profile, err := ParseProfile([]byte(ntProfile))
+	if err != nil {
+		t.Fatalf("Failed to parse profile: %v", err)
+	}
+	if err := RegisterProfile(profile); err != nil {
+		t.Fatalf("Failed to register profile: %v", err)
+	}
+
+	read := func(data []byte, opts ReadOptions) (*CauseList, error) {
+		cl := NewCauseList("Northern Territory", "Darwin", time.Now())
+		err := cl.ReadCauseListOptions(context.Background(), bytes.NewReader(data), int64(len(data)), opts)
+		return cl, err
+	}
+
+	t.Run("Default profile", func(t *testing.T) {
+		if got := DetectProfile("RESOURCE TENURE DIVISION\nWARDEN’S COURT KALGOORLIE"); got != DefaultProfile() {
+			t.Fatalf("Expected the default profile, got %s", got.Name)
+		}
+		if got := DetectProfile("nothing to go on"); got != DefaultProfile() {
+			t.Fatalf("Expected to fall back to the default profile, got %s", got.Name)
+		}
+	})
+
+	t.Run("Detected from headings", func(t *testing.T) {
+		cl, err := read([]byte(ntCauseList), DefaultReadOptions())
+		if err != nil {
+			t.Fatalf("Failed to read cause list: %v", err)
+		}
+		if cl.Report.Profile != "test-nt-mining-court" {
+			t.Fatalf("Expected the NT profile to be detected, got %q", cl.Report.Profile)
+		}
+
+		item, ok := cl.Items[0].(ObjectionItems)
+		if !ok || item.TenementNumber != "EL 32/101" || item.ObjectionNumber != 900123 ||
+			item.ApplicantName != "ARAFURA RESOURCES LTD" || item.ObjectorName != "TOP END PROSPECTING" {
+			t.Fatalf("Expected cells to be read in the profile's column order, got %+v", cl.Items[0])
+		}
+	})
+
+	t.Run("Explicit profile", func(t *testing.T) {
+		opts := DefaultReadOptions()
+		opts.Profile = DefaultProfile()
+		cl, err := read([]byte(ntCauseList), opts)
+		if !errors.Is(err, ErrNoItemsFound) {
+			t.Fatalf("Expected ErrNoItemsFound, got %v", err)
+		}
+		if cl.Report.Profile != "wa-wardens-court" {
+			t.Fatalf("Expected the chosen profile to be used, got %q", cl.Report.Profile)
+		}
+		if len(cl.Report.RowErrors) != 1 || cl.Report.RowErrors[0].Reason != "expected 6 cells, got 5" {
+			t.Fatalf("Expected the row to be checked against the default columns, got %v", cl.Report.RowErrors)
+		}
+	})
+
+	t.Run("Cover pages and noise", func(t *testing.T) {
+		data := minimalPDF(textPage("Mining Court of the Northern Territory", "Objections",
+			"1", "698561 BEACON MINERALS LIMITED E 15/2098 FMG RESOURCES PTY LTD", "Page 1 of 1"))
+
+		opts := DefaultReadOptions()
+		opts.Profile = DefaultProfile()
+		if _, err := read(data, opts); !errors.Is(err, ErrNoItemsFound) {
+			t.Fatalf("Expected the only page to be skipped as a cover page by the default profile, got %v", err)
+		}
+
+		cl, err := read(data, DefaultReadOptions())
+		if err != nil {
+			t.Fatalf("Failed to read cause list: %v", err)
+		}
+		if cl.Report.Profile != "test-nt-mining-court" {
+			t.Fatalf("Expected the NT profile to be detected from the first page, got %q", cl.Report.Profile)
+		}
+		item := cl.Items[0].(ObjectionItems)
+		if item.ApplicantName != "FMG RESOURCES PTY LTD" {
+			t.Fatalf("Expected the page number line to be dropped as noise, got applicant %q", item.ApplicantName)
+		}
+	})
+
+	t.Run("Invalid profiles", func(t *testing.T) {
+		tests := map[string]string{
+			"no name":         `sections: [{type: objection, columns: [matter_number]}]`,
+			"unknown section": `{name: x, sections: [{type: mention, columns: [matter_number]}]}`,
+			"unknown column":  `{name: x, sections: [{type: objection, columns: [matter_number, lawyer]}]}`,
+			"no matter":       `{name: x, sections: [{type: objection, columns: [tenement]}]}`,
+			"bad pattern":     `{name: x, noise_patterns: ["("], sections: [{type: objection, columns: [matter_number]}]}`,
+			"no group":        `{name: x, tenement_pattern: 'E \d+', sections: [{type: objection, columns: [matter_number]}]}`,
+		}
+		for name, yaml := range tests {
+			if _, err := ParseProfile([]byte(yaml)); err == nil {
+				t.Fatalf("Expected %s to be rejected", name)
+			} else if !strings.Contains(err.Error(), "profile") {
+				t.Fatalf("Expected the error for %s to name the profile, got %v", name, err)
+			}
+		}
+	})
+}
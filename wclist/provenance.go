@@ -0,0 +1,101 @@
+package wclist
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Provenance records where an item was read from, so that a match can be
+// checked against the original document
+type Provenance struct {
+	Page      int    `json:"page,omitempty"`  // 0 for formats without pages
+	Table     int    `json:"table,omitempty"` // Table of the row in formats without pages, counting from 1
+	FirstLine int    `json:"first_line"`      // Line within the page text, or row within its table
+	LastLine  int    `json:"last_line"`
+	Raw       string `json:"raw"`           // Source lines of the row, or its cells joined by " | "
+	BBox      BBox   `json:"bbox,omitzero"` // Area of the row on a PDF page, if known
+}
+
+// String describes where the item was read from, followed by its source text
+func (p Provenance) String() string {
+	var where string
+	switch {
+	case p.FirstLine == 0:
+		return "source unknown\n"
+	case p.Table > 0:
+		where = fmt.Sprintf("table %d, row %d", p.Table, p.FirstLine)
+	case p.Page == 0 && p.FirstLine == p.LastLine:
+		where = fmt.Sprintf("row %d", p.FirstLine)
+	case p.FirstLine == p.LastLine:
+		where = fmt.Sprintf("page %d, line %d", p.Page, p.FirstLine)
+	default:
+		where = fmt.Sprintf("page %d, lines %d-%d", p.Page, p.FirstLine, p.LastLine)
+	}
+	return where + "\n\n" + p.Raw + "\n"
+}
+
+// SameRow reports whether two sources are the same row of a document
+func (p Provenance) SameRow(other Provenance) bool {
+	return p.Page == other.Page && p.Table == other.Table && p.FirstLine == other.FirstLine
+}
+
+// BBox is a rectangle on a PDF page in points, measured from the bottom left
+// corner as in the PDF itself
+type BBox struct {
+	X0 float64 `json:"x0"`
+	Y0 float64 `json:"y0"`
+	X1 float64 `json:"x1"`
+	Y1 float64 `json:"y1"`
+}
+
+// IsZero reports whether the box is empty
+func (b BBox) IsZero() bool { return b == BBox{} }
+
+// Union returns the smallest box containing both boxes
+func (b BBox) Union(other BBox) BBox {
+	if b.IsZero() {
+		return other
+	}
+	if other.IsZero() {
+		return b
+	}
+	return BBox{
+		X0: min(b.X0, other.X0), Y0: min(b.Y0, other.Y0),
+		X1: max(b.X1, other.X1), Y1: max(b.Y1, other.Y1),
+	}
+}
+
+// ItemSource returns where an item was read from. Items that don't embed
+// CLIItems have no provenance.
+func ItemSource(item CauseListItem) Provenance {
+	if v, ok := item.(interface{ GetSource() Provenance }); ok {
+		return v.GetSource()
+	}
+	return Provenance{}
+}
+
+// withSource returns a copy of the item recording where it was read from
+func withSource(item CauseListItem, source Provenance) CauseListItem {
+	return updateCLIItems(item, func(c *CLIItems) { c.Source = source })
+}
+
+// updateCLIItems returns a copy of the item with its embedded CLIItems
+// changed by update. Items are values, so this works on a copy through
+// reflection, which also reaches the items of registered matter types.
+// Items that don't embed CLIItems are returned unchanged.
+func updateCLIItems(item CauseListItem, update func(*CLIItems)) CauseListItem {
+	if item == nil {
+		return nil
+	}
+	v := reflect.New(reflect.TypeOf(item)).Elem()
+	v.Set(reflect.ValueOf(item))
+	if v.Kind() != reflect.Struct {
+		return item
+	}
+	field := v.FieldByName("CLIItems")
+	if !field.IsValid() || field.Type() != reflect.TypeOf(CLIItems{}) {
+		return item
+	}
+	update(field.Addr().Interface().(*CLIItems))
+	return v.Interface().(CauseListItem)
+}
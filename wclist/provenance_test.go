@@ -0,0 +1,100 @@
+package wclist
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ledongthuc/pdf"
+)
+
+func TestProvenance(t *testing.T) {
+	read := func(data []byte) *CauseList {
+		cl := NewCauseList("Western Australia", "Kalgoorlie", time.Now())
+		if err := cl.ReadCauseListOptions(context.Background(), bytes.NewReader(data), int64(len(data)), DefaultReadOptions()); err != nil {
+			t.Fatalf("Failed to read cause list: %v", err)
+		}
+		return cl
+	}
+
+	t.Run("PDF rows", func(t *testing.T) {
+		cl := read(minimalPDF(textPage("WARDEN'S COURT KALGOORLIE"),
+			textPage("OBJECTIONS", "1", "698561", "KARORA (HIGGINSVILLE) PTY LTD", "E 15/2082", "FMG RESOURCES PTY LTD")))
+
+		source := ItemSource(cl.Items[0])
+		if source.Page != 2 || source.FirstLine != 3 || source.LastLine != 7 {
+			t.Fatalf("Expected the row to come from page 2, lines 3-7, got %+v", source)
+		}
+		if !strings.HasPrefix(source.Raw, "1\n698561\nKARORA") {
+			t.Fatalf("Expected the raw lines of the row, got %q", source.Raw)
+		}
+
+		// Lines are 14 points apart from 720 down, with 12 point text
+		box := source.BBox
+		if box.X0 != 72 || math.Abs(box.Y1-(706+9.6)) > 0.01 || math.Abs(box.Y0-(650-2.4)) > 0.01 || box.X1 <= 200 {
+			t.Fatalf("Expected the box to cover the row's lines, got %+v", box)
+		}
+		if len(cl.Report.UnboxedPages) != 0 {
+			t.Fatalf("Expected every page to be laid out, got %v", cl.Report.UnboxedPages)
+		}
+
+		data, err := json.Marshal(cl)
+		if err != nil {
+			t.Fatalf("Failed to encode cause list: %v", err)
+		}
+		decoded := &CauseList{}
+		if err := json.Unmarshal(data, decoded); err != nil {
+			t.Fatalf("Failed to decode cause list: %v", err)
+		}
+		if ItemSource(decoded.Items[0]) != source {
+			t.Fatalf("Expected the source to survive JSON, got %+v", ItemSource(decoded.Items[0]))
+		}
+		if got := source.String(); !strings.HasPrefix(got, "page 2, lines 3-7\n\n1\n698561\n") {
+			t.Fatalf("Unexpected rendering of the source: %q", got)
+		}
+	})
+
+	t.Run("Pages without layout", func(t *testing.T) {
+		data := minimalPDF(textPage("OBJECTIONS"))
+		reader, err := pdf.NewReader(bytes.NewReader(data), int64(len(data)))
+		if err != nil {
+			t.Fatalf("Failed to open PDF: %v", err)
+		}
+		item := withSource(ObjectionItems{CLIItems: CLIItems{MatterNumber: 1}}, Provenance{Page: 1, FirstLine: 1, LastLine: 1})
+
+		var report ParseReport
+		items := withRowBoxes(reader.Page(1), 1, "OTHER TEXT", []CauseListItem{item}, &report)
+		if !ItemSource(items[0]).BBox.IsZero() || !reflect.DeepEqual(report.UnboxedPages, []int{1}) {
+			t.Fatalf("Expected the page to be reported without boxes, got %+v and %v", ItemSource(items[0]), report.UnboxedPages)
+		}
+	})
+
+	t.Run("Table rows", func(t *testing.T) {
+		cl := read([]byte(htmlOtherMatters))
+
+		source := ItemSource(cl.Items[1])
+		want := Provenance{Table: 2, FirstLine: 2, LastLine: 2, Raw: "21 | M 15/1830 | GOLDFIELDS PROSPECTING PTY LTD | NORTHERN STAR RESOURCES LTD | Declaration of boundaries | Adjourned"}
+		if source != want {
+			t.Fatalf("Expected %+v, got %+v", want, source)
+		}
+		if got := source.String(); !strings.HasPrefix(got, "table 2, row 2\n\n21 | M 15/1830") {
+			t.Fatalf("Unexpected rendering of the source: %q", got)
+		}
+	})
+
+	t.Run("Registered types", func(t *testing.T) {
+		RegisterSectionParser(calloverParser{})
+		cl := read([]byte(`<html><body><h2>Callover</h2><table>
+<tr><td>4</td><td>P 15/6490</td><td>GOLDEN MILE PROSPECTING</td></tr>
+</table></body></html>`))
+
+		if source := ItemSource(cl.Items[0]); source.FirstLine != 1 || source.Raw != "4 | P 15/6490 | GOLDEN MILE PROSPECTING" {
+			t.Fatalf("Expected items of registered types to record their source, got %+v", source)
+		}
+	})
+}
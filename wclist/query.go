@@ -0,0 +1,380 @@
+package wclist
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// Query selects cause list items
+type Query interface {
+	Match(item CauseListItem) bool
+}
+
+// Operator is a comparison used by a FieldFilter
+type Operator int
+
+const (
+	OpContains Operator = iota // Field contains the value
+	OpEquals                   // Field equals the value
+	OpPrefix                   // Field starts with the value
+)
+
+// FieldFilter matches items whose field compares to a value, ignoring case.
+// The special field names "party" (applying or responding party) and "any"
+// (every item field) are also accepted.
+type FieldFilter struct {
+	Field string
+	Op    Operator
+	Value string
+}
+
+// Match checks whether the item's field satisfies the filter
+func (f FieldFilter) Match(item CauseListItem) bool {
+	for _, value := range fieldValues(item, f.Field) {
+		if compareField(value, f.Op, f.Value) {
+			return true
+		}
+	}
+	return false
+}
+
+// TenementPrefix matches items whose tenement number starts with the prefix,
+// e.g. "E 15/" for exploration licences in mineral field 15
+func TenementPrefix(prefix string) Query {
+	return FieldFilter{Field: "tenement", Op: OpPrefix, Value: prefix}
+}
+
+type andQuery []Query
+
+func (q andQuery) Match(item CauseListItem) bool {
+	for _, sub := range q {
+		if !sub.Match(item) {
+			return false
+		}
+	}
+	return true
+}
+
+type orQuery []Query
+
+func (q orQuery) Match(item CauseListItem) bool {
+	for _, sub := range q {
+		if sub.Match(item) {
+			return true
+		}
+	}
+	return false
+}
+
+type notQuery struct{ q Query }
+
+func (q notQuery) Match(item CauseListItem) bool { return !q.q.Match(item) }
+
+// And matches items matched by every query
+func And(queries ...Query) Query { return andQuery(queries) }
+
+// Or matches items matched by any query
+func Or(queries ...Query) Query { return orQuery(queries) }
+
+// Not matches items not matched by the query
+func Not(query Query) Query { return notQuery{query} }
+
+// fieldValues returns the values of a named field on an item
+func fieldValues(item CauseListItem, name string) []string {
+	switch name {
+	case "party":
+		return []string{item.GetApplyingParty(), item.GetRespondingParty()}
+	case "any":
+		values := make([]string, len(ItemFields))
+		for i, field := range ItemFields {
+			values[i] = field.Value(item)
+		}
+		return values
+	}
+
+	if field, ok := LookupItemField(name); ok {
+		return []string{field.Value(item)}
+	}
+	return nil
+}
+
+// compareField compares a field value against a filter value, ignoring case
+func compareField(value string, op Operator, want string) bool {
+	value = strings.ToLower(value)
+	want = strings.ToLower(want)
+
+	switch op {
+	case OpEquals:
+		return value == want
+	case OpPrefix:
+		return strings.HasPrefix(value, want)
+	default:
+		return strings.Contains(value, want)
+	}
+}
+
+// Query returns the items in the cause list matched by the query
+func (cl *CauseList) Query(q Query) []CauseListItem {
+	return FilterItems(cl.Items, q)
+}
+
+// FilterItems returns the items matched by the query, in their original order
+func FilterItems(items []CauseListItem, q Query) []CauseListItem {
+	var matched []CauseListItem
+	for _, item := range items {
+		if q.Match(item) {
+			matched = append(matched, item)
+		}
+	}
+	return matched
+}
+
+// ParseQuery parses a text query such as
+//
+//	type=objection AND objector:"Prospector" AND NOT comments:withdrawn
+//	tenement^"E 15/" OR field=16
+//
+// Terms take the form field:value (contains), field=value (equals) or
+// field^value (starts with). Values containing spaces must be quoted.
+// A bare word matches any field. Terms are combined with AND, OR, NOT and
+// parentheses; adjacent terms without an operator are ANDed.
+func ParseQuery(text string) (Query, error) {
+	tokens, err := lexQuery(text)
+	if err != nil {
+		return nil, err
+	}
+	if len(tokens) == 0 {
+		return nil, fmt.Errorf("empty query")
+	}
+
+	p := &queryParser{tokens: tokens}
+	q, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.tokens) {
+		return nil, fmt.Errorf("unexpected %q in query", p.tokens[p.pos].text)
+	}
+	return q, nil
+}
+
+type queryTokenKind int
+
+const (
+	tokenTerm queryTokenKind = iota
+	tokenAnd
+	tokenOr
+	tokenNot
+	tokenOpen
+	tokenClose
+)
+
+type queryToken struct {
+	kind   queryTokenKind
+	text   string
+	filter FieldFilter
+}
+
+// lexQuery splits a text query into tokens
+func lexQuery(text string) ([]queryToken, error) {
+	var tokens []queryToken
+	runes := []rune(text)
+
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+			continue
+		case r == '(':
+			tokens = append(tokens, queryToken{kind: tokenOpen, text: "("})
+			i++
+			continue
+		case r == ')':
+			tokens = append(tokens, queryToken{kind: tokenClose, text: ")"})
+			i++
+			continue
+		}
+
+		// Read a field name or bare word up to an operator
+		start := i
+		for i < len(runes) && !unicode.IsSpace(runes[i]) && !strings.ContainsRune(`():=^"`, runes[i]) {
+			i++
+		}
+		word := string(runes[start:i])
+
+		if i < len(runes) && strings.ContainsRune(":=^", runes[i]) {
+			op := map[rune]Operator{':': OpContains, '=': OpEquals, '^': OpPrefix}[runes[i]]
+			i++
+			value, next, err := readQueryValue(runes, i)
+			if err != nil {
+				return nil, err
+			}
+			i = next
+
+			field := strings.ToLower(word)
+			if _, ok := LookupItemField(field); !ok && field != "party" && field != "any" {
+				return nil, fmt.Errorf("unknown query field %q", word)
+			}
+			tokens = append(tokens, queryToken{
+				kind:   tokenTerm,
+				text:   string(runes[start:i]),
+				filter: FieldFilter{Field: field, Op: op, Value: value},
+			})
+			continue
+		}
+
+		if word == "" {
+			value, next, err := readQueryValue(runes, i)
+			if err != nil {
+				return nil, err
+			}
+			i = next
+			tokens = append(tokens, queryToken{
+				kind:   tokenTerm,
+				text:   string(runes[start:i]),
+				filter: FieldFilter{Field: "any", Op: OpContains, Value: value},
+			})
+			continue
+		}
+
+		switch word {
+		case "AND":
+			tokens = append(tokens, queryToken{kind: tokenAnd, text: word})
+		case "OR":
+			tokens = append(tokens, queryToken{kind: tokenOr, text: word})
+		case "NOT":
+			tokens = append(tokens, queryToken{kind: tokenNot, text: word})
+		default:
+			tokens = append(tokens, queryToken{
+				kind:   tokenTerm,
+				text:   word,
+				filter: FieldFilter{Field: "any", Op: OpContains, Value: word},
+			})
+		}
+	}
+
+	return tokens, nil
+}
+
+// readQueryValue reads a quoted or bare value starting at i
+func readQueryValue(runes []rune, i int) (string, int, error) {
+	if i < len(runes) && runes[i] == '"' {
+		end := i + 1
+		for end < len(runes) && runes[end] != '"' {
+			end++
+		}
+		if end == len(runes) {
+			return "", 0, fmt.Errorf("unterminated quote in query")
+		}
+		return string(runes[i+1 : end]), end + 1, nil
+	}
+
+	start := i
+	for i < len(runes) && !unicode.IsSpace(runes[i]) && runes[i] != '(' && runes[i] != ')' {
+		i++
+	}
+	if i == start {
+		return "", 0, fmt.Errorf("missing value in query")
+	}
+	return string(runes[start:i]), i, nil
+}
+
+// queryParser builds a Query from tokens by recursive descent
+type queryParser struct {
+	tokens []queryToken
+	pos    int
+}
+
+func (p *queryParser) peek() (queryToken, bool) {
+	if p.pos < len(p.tokens) {
+		return p.tokens[p.pos], true
+	}
+	return queryToken{}, false
+}
+
+func (p *queryParser) parseOr() (Query, error) {
+	q, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+
+	queries := []Query{q}
+	for {
+		token, ok := p.peek()
+		if !ok || token.kind != tokenOr {
+			break
+		}
+		p.pos++
+		q, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		queries = append(queries, q)
+	}
+
+	if len(queries) == 1 {
+		return queries[0], nil
+	}
+	return Or(queries...), nil
+}
+
+func (p *queryParser) parseAnd() (Query, error) {
+	q, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+
+	queries := []Query{q}
+	for {
+		token, ok := p.peek()
+		if !ok || token.kind == tokenOr || token.kind == tokenClose {
+			break
+		}
+		if token.kind == tokenAnd {
+			p.pos++
+		}
+		q, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		queries = append(queries, q)
+	}
+
+	if len(queries) == 1 {
+		return queries[0], nil
+	}
+	return And(queries...), nil
+}
+
+func (p *queryParser) parseUnary() (Query, error) {
+	token, ok := p.peek()
+	if !ok {
+		return nil, fmt.Errorf("unexpected end of query")
+	}
+	p.pos++
+
+	switch token.kind {
+	case tokenNot:
+		q, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return Not(q), nil
+	case tokenOpen:
+		q, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if next, ok := p.peek(); !ok || next.kind != tokenClose {
+			return nil, fmt.Errorf("missing closing parenthesis in query")
+		}
+		p.pos++
+		return q, nil
+	case tokenTerm:
+		return token.filter, nil
+	}
+
+	return nil, fmt.Errorf("unexpected %q in query", token.text)
+}
@@ -0,0 +1,66 @@
+package wclist
+
+import "testing"
+
+func TestParseQuery(t *testing.T) {
+	items := []CauseListItem{
+		ObjectionItems{
+			CLIItems:        CLIItems{MatterNumber: 2, TenementNumber: "E 15/2098"},
+			ObjectionNumber: 712980,
+			ObjectorName:    "BEACON MINERALS LIMITED",
+			ApplicantName:   "WEST AUSTRALIAN PROSPECTORS PTY LTD",
+		},
+		ForfeitureItems{
+			CLIItems:       CLIItems{MatterNumber: 84, TenementNumber: "E 16/396", Comments: "Adjourned", Status: StatusAdjourned},
+			ApplicantName:  "ASHCROFT, Sean Cameron",
+			RespondentName: "GOLD TIGER HOLDINGS (AUSTRALIA) PTY LTD",
+		},
+		ExemptionItems{
+			CLIItems:       CLIItems{MatterNumber: 90, TenementNumber: "P 15/6512"},
+			ApplicantName:  "PROSPECTOR RESOURCES PTY LTD",
+			RespondentName: "SMITH, John",
+		},
+	}
+
+	tests := []struct {
+		query string
+		want  []uint64
+	}{
+		{`objector:prospector`, nil},
+		{`type=objection AND applicant:"Prospectors"`, []uint64{2}},
+		{`field=15`, []uint64{2, 90}},
+		{`tenement^"E 1"`, []uint64{2, 84}},
+		{`type=forfeiture comments:adjourned`, []uint64{84}},
+		{`NOT status=adjourned`, []uint64{2, 90}},
+		{`prospector NOT type=objection`, []uint64{90}},
+		{`(field=16 OR party:smith) AND NOT matter=84`, []uint64{90}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			q, err := ParseQuery(tt.query)
+			if err != nil {
+				t.Fatalf("Failed to parse query: %v", err)
+			}
+
+			var got []uint64
+			for _, item := range FilterItems(items, q) {
+				got = append(got, item.GetMatterNumber())
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("Expected matters %v, got %v", tt.want, got)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Fatalf("Expected matters %v, got %v", tt.want, got)
+				}
+			}
+		})
+	}
+
+	for _, bad := range []string{``, `colour:red`, `objector:"unterminated`, `(field=15`, `AND`} {
+		if _, err := ParseQuery(bad); err == nil {
+			t.Errorf("Expected an error parsing %q", bad)
+		}
+	}
+}
@@ -0,0 +1,98 @@
+package wclist
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ParseReport describes how a cause list document was read and the
+// problems found along the way. Pages and rows with errors are skipped;
+// the rest of the document is still read.
+type ParseReport struct {
+	Format     string          `json:"format"`
+	Profile    string          `json:"profile"` // Name of the profile the document was read with
+	Pages      int             `json:"pages"`   // Pages in the document, 0 for formats without pages
+	PageErrors []PageError     `json:"page_errors"`
+	RowErrors  []RowParseError `json:"row_errors"`
+	OCRPages   []int           `json:"ocr_pages"` // Scanned pages read by OCR
+
+	// UnboxedPages are the pages whose rows have no box, because the layout
+	// of the page didn't reproduce its text
+	UnboxedPages []int `json:"unboxed_pages"`
+
+	// Sitting is the sitting named on the cover of the document, or in the
+	// text above the tables of HTML and DOCX lists. Whatever it doesn't
+	// name is left empty.
+	Sitting Sitting `json:"sitting,omitzero"`
+
+	// Released is when the document was last modified, according to the
+	// document itself. It is zero for documents that don't record it.
+	Released time.Time `json:"released,omitzero"`
+
+	// CacheKey is the key of the read in ReadOptions.Cache, see CacheKey.
+	// It is empty without a cache, and for encrypted PDFs, which aren't
+	// cached.
+	CacheKey string `json:"cache_key"`
+	Cached   bool   `json:"cached"` // Whether the items came from the cache
+
+	// CacheError says why the cache couldn't be read or written. The
+	// document is still read.
+	CacheError string `json:"cache_error,omitempty"`
+}
+
+// PageError is a problem that stopped one page of a document being read
+type PageError struct {
+	Page int
+	Err  error
+}
+
+func (e PageError) Error() string { return fmt.Sprintf("page %d: %v", e.Page, e.Err) }
+
+func (e PageError) Unwrap() error { return e.Err }
+
+// MarshalJSON encodes the page number and error message
+func (e PageError) MarshalJSON() ([]byte, error) {
+	var msg string
+	if e.Err != nil {
+		msg = e.Err.Error()
+	}
+	return json.Marshal(map[string]interface{}{
+		"page":  e.Page,
+		"error": msg,
+	})
+}
+
+// UnmarshalJSON decodes a page error encoded by MarshalJSON. Only the
+// message of the error is kept, so it no longer matches the errors of
+// this package with errors.Is.
+func (e *PageError) UnmarshalJSON(data []byte) error {
+	var encoded struct {
+		Page  int    `json:"page"`
+		Error string `json:"error"`
+	}
+	if err := json.Unmarshal(data, &encoded); err != nil {
+		return err
+	}
+	e.Page, e.Err = encoded.Page, nil
+	if encoded.Error != "" {
+		e.Err = errors.New(encoded.Error)
+	}
+	return nil
+}
+
+// addPageError records a page that couldn't be read
+func (r *ParseReport) addPageError(page int, err error) {
+	r.PageErrors = append(r.PageErrors, PageError{Page: page, Err: err})
+}
+
+// addRowError records a row that couldn't be parsed, setting where it was
+// found from the first line of source
+func (r *ParseReport) addRowError(source Provenance, err error) {
+	var rowErr RowParseError
+	if errors.As(err, &rowErr) {
+		rowErr.Page, rowErr.Table, rowErr.Line = source.Page, source.Table, source.FirstLine
+		r.RowErrors = append(r.RowErrors, rowErr)
+	}
+}
@@ -0,0 +1,520 @@
+package wclist
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// SectionParser reads the rows of one type of matter, such as objections,
+// from a cause list. Each matter type registers a parser, and profiles list
+// the types their cause lists contain.
+//
+// Items of types other than the built-in ones should implement
+// ItemType() string, returning the parser's Type, so that they can be told
+// apart when exported and encoded as JSON.
+type SectionParser interface {
+	// Type names the matter type, e.g. "objection". Profiles refer to
+	// sections by their type.
+	Type() string
+	// Headings are words in a page or table heading that start a section
+	// of this type, used when the profile doesn't list its own
+	Headings() []string
+	// HeaderKeywords are words in the header rows of the section's tables
+	HeaderKeywords() []string
+	// Columns is the column order of table rows, used when the profile
+	// doesn't list its own. Columns ending in "?" may be missing from the
+	// end of a row.
+	Columns() []string
+	// ParseText builds an item from a row reconstructed from PDF text
+	ParseText(row TextRow) (CauseListItem, error)
+	// ParseCells builds an item from the cells of a table row
+	ParseCells(row TableRow) (CauseListItem, error)
+	// DecodeItem decodes an item of this type from its JSON encoding
+	DecodeItem(data []byte) (CauseListItem, error)
+}
+
+// TextRow is a table row reconstructed from the lines of PDF text between
+// one matter number and the next
+type TextRow struct {
+	MatterNumber uint64
+	Text         string // The row's lines joined with spaces, starting with the matter number
+
+	tenement *regexp.Regexp
+}
+
+// FindTenement returns the first tenement in text, as matched by the
+// profile's tenement pattern, and where it starts. The position is -1 if
+// there is no tenement.
+func (r TextRow) FindTenement(text string) (string, int) {
+	match := r.tenement.FindStringSubmatchIndex(text)
+	if match == nil || match[2] < 0 {
+		return "", -1
+	}
+	return text[match[2]:match[3]], match[2]
+}
+
+// TableRow is a row of a table, with its cells named by the section's columns
+type TableRow struct {
+	MatterNumber uint64
+	Cells        map[string]string // Missing optional cells are empty
+}
+
+var (
+	sectionParsersMu sync.RWMutex
+	sectionParsers   = []SectionParser{
+		objectionParser{}, forfeitureParser{}, exemptionParser{},
+		restorationParser{}, plaintParser{}, applicationParser{},
+	}
+
+	// sectionHeaderKeywords holds the normalised header keywords of the
+	// registered parsers, built when first needed after a registration
+	sectionHeaderKeywords []string
+)
+
+// RegisterSectionParser adds a parser for a matter type, replacing any
+// registered parser of the same type. Profiles can only use types that have
+// been registered, and cause lists read with profiles that don't list the
+// type still detect it by its headings, after the profile's own sections.
+func RegisterSectionParser(parser SectionParser) {
+	sectionParsersMu.Lock()
+	defer sectionParsersMu.Unlock()
+	sectionHeaderKeywords = nil
+	for i, registered := range sectionParsers {
+		if registered.Type() == parser.Type() {
+			sectionParsers[i] = parser
+			return
+		}
+	}
+	sectionParsers = append(sectionParsers, parser)
+}
+
+// headerKeywords returns the normalised header keywords of every registered
+// parser
+func headerKeywords() []string {
+	sectionParsersMu.RLock()
+	keywords := sectionHeaderKeywords
+	sectionParsersMu.RUnlock()
+	if keywords != nil {
+		return keywords
+	}
+
+	sectionParsersMu.Lock()
+	defer sectionParsersMu.Unlock()
+	if sectionHeaderKeywords == nil {
+		sectionHeaderKeywords = []string{}
+		for _, parser := range sectionParsers {
+			for _, keyword := range parser.HeaderKeywords() {
+				sectionHeaderKeywords = append(sectionHeaderKeywords, normalizeProfileText(keyword))
+			}
+		}
+	}
+	return sectionHeaderKeywords
+}
+
+// SectionParsers returns the registered parsers, built-in parsers first
+func SectionParsers() []SectionParser {
+	sectionParsersMu.RLock()
+	defer sectionParsersMu.RUnlock()
+	return append([]SectionParser(nil), sectionParsers...)
+}
+
+// lookupSectionParser returns the parser for a matter type
+func lookupSectionParser(sectionType string) (SectionParser, bool) {
+	sectionParsersMu.RLock()
+	defer sectionParsersMu.RUnlock()
+	for _, parser := range sectionParsers {
+		if parser.Type() == sectionType {
+			return parser, true
+		}
+	}
+	return nil, false
+}
+
+// asRowError turns an error from a section parser into a RowParseError so
+// that it can be recorded in the report
+func asRowError(err error, section, raw string) error {
+	var rowErr RowParseError
+	if errors.As(err, &rowErr) {
+		return rowErr
+	}
+	return rowError(section, raw, "%v", err)
+}
+
+// objectionParser reads objections to tenement applications
+type objectionParser struct{}
+
+func (objectionParser) Type() string { return "objection" }
+
+func (objectionParser) Headings() []string { return []string{"objection", "objector"} }
+
+func (objectionParser) HeaderKeywords() []string {
+	return []string{"matter number", "objection number", "objector", "tenement affected", "applicant", "comments"}
+}
+
+func (objectionParser) Columns() []string {
+	return []string{"matter_number", "objection_number", "objector", "tenement", "applicant", "comments"}
+}
+
+// objectionNumberPattern matches the objection number at the start of a row's text
+var objectionNumberPattern = regexp.MustCompile(`^(\d{6,})\s+`)
+
+// matterNumberPrefixPattern matches the matter number at the start of a row's text
+var matterNumberPrefixPattern = regexp.MustCompile(`^\d+\s+`)
+
+// ParseText parses objection data from the combined text of a row
+func (objectionParser) ParseText(row TextRow) (CauseListItem, error) {
+	// Expected pattern: MATTER_NUM OBJECTION_NUM OBJECTOR TENEMENT APPLICANT
+	// Use regex to match the pattern
+	raw := row.Text
+
+	// Remove the matter number from the beginning
+	content := matterNumberPrefixPattern.ReplaceAllString(row.Text, "")
+
+	// Extract objection number (first large number)
+	objectionMatches := objectionNumberPattern.FindStringSubmatch(content)
+	if len(objectionMatches) < 2 {
+		return nil, rowError("objection", raw, "missing objection number")
+	}
+
+	objectionNum, err := strconv.ParseUint(objectionMatches[1], 10, 64)
+	if err != nil {
+		return nil, rowError("objection", raw, "invalid objection number %q", objectionMatches[1])
+	}
+
+	// Remove objection number from content
+	content = objectionNumberPattern.ReplaceAllString(content, "")
+
+	// Find tenement pattern (like "E 15/2082", "L 28/100", etc.)
+	tenement, tenementPos := row.FindTenement(content)
+	if tenementPos < 0 {
+		return nil, rowError("objection", raw, "missing tenement number")
+	}
+
+	// Everything before the tenement is the objector
+	objector := strings.TrimSpace(content[:tenementPos])
+
+	// Everything after the tenement (and tenement itself) contains the
+	// applicant, followed by any comments
+	afterTenement := content[tenementPos+len(tenement):]
+	applicant, comments := splitComments(afterTenement)
+
+	return ObjectionItems{
+		CLIItems:        newCLIItems(row.MatterNumber, tenement, comments),
+		ObjectionNumber: objectionNum,
+		ObjectorName:    objector,
+		ApplicantName:   applicant,
+	}, nil
+}
+
+// ParseCells builds an objection item from the cells of a row
+func (objectionParser) ParseCells(row TableRow) (CauseListItem, error) {
+	objectionNumber, _ := strconv.ParseUint(row.Cells["objection_number"], 10, 64)
+
+	return ObjectionItems{
+		CLIItems:        newCLIItems(row.MatterNumber, row.Cells["tenement"], row.Cells["comments"]),
+		ObjectionNumber: objectionNumber,
+		ObjectorName:    row.Cells["objector"],
+		ApplicantName:   row.Cells["applicant"],
+	}, nil
+}
+
+func (objectionParser) DecodeItem(data []byte) (CauseListItem, error) {
+	return decodeItemAs[ObjectionItems](data)
+}
+
+// forfeitureParser reads applications for forfeiture of tenements
+type forfeitureParser struct{}
+
+func (forfeitureParser) Type() string { return "forfeiture" }
+
+func (forfeitureParser) Headings() []string { return []string{"forfeiture", "forfeit"} }
+
+func (forfeitureParser) HeaderKeywords() []string {
+	return []string{"matter number", "tenement affected", "applicant", "respondent", "comments"}
+}
+
+func (forfeitureParser) Columns() []string {
+	return []string{"matter_number", "tenement", "applicant", "respondent", "comments"}
+}
+
+// ParseText is a placeholder until the PDF layout of forfeitures is known
+func (forfeitureParser) ParseText(row TextRow) (CauseListItem, error) {
+	// TODO: Implement forfeiture parsing based on actual format
+	return nil, rowError("forfeiture", row.Text, "forfeiture rows can't be read from PDF text yet")
+}
+
+// ParseCells builds a forfeiture item from the cells of a row
+func (forfeitureParser) ParseCells(row TableRow) (CauseListItem, error) {
+	return ForfeitureItems{
+		CLIItems:       newCLIItems(row.MatterNumber, row.Cells["tenement"], row.Cells["comments"]),
+		ApplicantName:  row.Cells["applicant"],
+		RespondentName: row.Cells["respondent"],
+	}, nil
+}
+
+func (forfeitureParser) DecodeItem(data []byte) (CauseListItem, error) {
+	return decodeItemAs[ForfeitureItems](data)
+}
+
+// exemptionParser reads applications for exemption from expenditure conditions
+type exemptionParser struct{}
+
+func (exemptionParser) Type() string { return "exemption" }
+
+func (exemptionParser) Headings() []string { return []string{"exemption", "exempt"} }
+
+func (exemptionParser) HeaderKeywords() []string {
+	return []string{"matter number", "tenement affected", "applicant", "respondent", "comments", "exemption"}
+}
+
+func (exemptionParser) Columns() []string {
+	return []string{"matter_number", "tenement", "applicant", "respondent", "comments?"}
+}
+
+// ParseText is a placeholder until the PDF layout of exemptions is known
+func (exemptionParser) ParseText(row TextRow) (CauseListItem, error) {
+	// TODO: Implement exemption parsing based on actual format
+	return nil, rowError("exemption", row.Text, "exemption rows can't be read from PDF text yet")
+}
+
+// ParseCells builds an exemption item from the cells of a row
+func (exemptionParser) ParseCells(row TableRow) (CauseListItem, error) {
+	return ExemptionItems{
+		CLIItems:       newCLIItems(row.MatterNumber, row.Cells["tenement"], row.Cells["comments"]),
+		ApplicantName:  row.Cells["applicant"],
+		RespondentName: row.Cells["respondent"],
+	}, nil
+}
+
+func (exemptionParser) DecodeItem(data []byte) (CauseListItem, error) {
+	return decodeItemAs[ExemptionItems](data)
+}
+
+// listDatePattern matches a date written as day/month/year
+var listDatePattern = regexp.MustCompile(`\b\d{1,2}/\d{1,2}/\d{4}\b`)
+
+// parseListDate parses a date as written in cause lists, e.g. "12/11/2025"
+// or "12 November 2025"
+func parseListDate(value string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	for _, layout := range []string{"2/1/2006", "2 January 2006", "2 Jan 2006", "2006-01-02"} {
+		if date, err := time.Parse(layout, value); err == nil {
+			return date, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid date %q", value)
+}
+
+// rowAfterTenement returns the tenement in a row's text and the text after
+// it, with the matter number before it removed
+func rowAfterTenement(row TextRow, section string) (string, string, error) {
+	tenement, pos := row.FindTenement(row.Text)
+	if pos < 0 {
+		return "", "", rowError(section, row.Text, "missing tenement number")
+	}
+	return tenement, strings.TrimSpace(row.Text[pos+len(tenement):]), nil
+}
+
+// applicationParser reads tenement applications heard for a recommendation,
+// such as applications for mining leases and miscellaneous licences
+type applicationParser struct{}
+
+func (applicationParser) Type() string { return "application" }
+
+func (applicationParser) Headings() []string {
+	return []string{"recommendation", "applications for mining lease", "applications for miscellaneous licence", "applications for prospecting licence"}
+}
+
+func (applicationParser) HeaderKeywords() []string {
+	return []string{"tenement applied for", "date of application", "application date"}
+}
+
+func (applicationParser) Columns() []string {
+	return []string{"matter_number", "tenement", "applicant", "application_date", "comments?"}
+}
+
+// ParseText parses an application from the text of a row:
+// MATTER_NUMBER TENEMENT APPLICANT APPLICATION_DATE COMMENTS
+func (applicationParser) ParseText(row TextRow) (CauseListItem, error) {
+	tenement, rest, err := rowAfterTenement(row, "application")
+	if err != nil {
+		return nil, err
+	}
+
+	// Comments such as "Adjourned to 12/11/2025" hold dates of their own, so
+	// the application date is only looked for before them
+	rest, comments := splitComments(rest)
+	loc := listDatePattern.FindStringIndex(rest)
+	if loc == nil {
+		return ApplicationItems{
+			CLIItems:      newCLIItems(row.MatterNumber, tenement, comments),
+			ApplicantName: rest,
+		}, nil
+	}
+
+	date, err := parseListDate(rest[loc[0]:loc[1]])
+	if err != nil {
+		return nil, rowError("application", row.Text, "%v", err)
+	}
+	comments = strings.TrimSpace(rest[loc[1]:] + " " + comments)
+	return ApplicationItems{
+		CLIItems:        newCLIItems(row.MatterNumber, tenement, comments),
+		ApplicantName:   strings.TrimSpace(rest[:loc[0]]),
+		ApplicationDate: date,
+	}, nil
+}
+
+// ParseCells builds an application item from the cells of a row
+func (applicationParser) ParseCells(row TableRow) (CauseListItem, error) {
+	item := ApplicationItems{
+		CLIItems:      newCLIItems(row.MatterNumber, row.Cells["tenement"], row.Cells["comments"]),
+		ApplicantName: row.Cells["applicant"],
+	}
+	if value := row.Cells["application_date"]; value != "" {
+		date, err := parseListDate(value)
+		if err != nil {
+			return nil, rowError("application", value, "%v", err)
+		}
+		item.ApplicationDate = date
+	}
+	return item, nil
+}
+
+func (applicationParser) DecodeItem(data []byte) (CauseListItem, error) {
+	return decodeItemAs[ApplicationItems](data)
+}
+
+// plaintParser reads plaints between parties over a tenement
+type plaintParser struct{}
+
+func (plaintParser) Type() string { return "plaint" }
+
+func (plaintParser) Headings() []string { return []string{"plaint"} }
+
+func (plaintParser) HeaderKeywords() []string {
+	return []string{"plaintiff", "defendant", "relief sought"}
+}
+
+func (plaintParser) Columns() []string {
+	return []string{"matter_number", "tenement", "plaintiff", "defendant", "relief_sought", "comments?"}
+}
+
+var (
+	// partiesSeparatorPattern matches the "v" between plaintiff and defendant
+	partiesSeparatorPattern = regexp.MustCompile(`(?i)\s+-?v(?:s|\.)?-?\s+`)
+	// plaintReliefPattern matches the start of the relief sought in a plaint
+	plaintReliefPattern = regexp.MustCompile(`(?i)\b(declaration|orders?|injunction|damages|compensation|relief|that the)\b`)
+)
+
+// ParseText parses a plaint from the text of a row:
+// MATTER_NUMBER TENEMENT PLAINTIFF v DEFENDANT RELIEF_SOUGHT
+func (plaintParser) ParseText(row TextRow) (CauseListItem, error) {
+	tenement, rest, err := rowAfterTenement(row, "plaint")
+	if err != nil {
+		return nil, err
+	}
+
+	separator := partiesSeparatorPattern.FindStringIndex(rest)
+	if separator == nil {
+		return nil, rowError("plaint", row.Text, "missing \"v\" between plaintiff and defendant")
+	}
+	plaintiff := rest[:separator[0]]
+	defendant, comments := splitComments(rest[separator[1]:])
+
+	var relief string
+	if loc := plaintReliefPattern.FindStringIndex(defendant); loc != nil {
+		defendant, relief = defendant[:loc[0]], defendant[loc[0]:]
+	}
+
+	return PlaintItems{
+		CLIItems:      newCLIItems(row.MatterNumber, tenement, comments),
+		PlaintiffName: strings.TrimSpace(plaintiff),
+		DefendantName: strings.TrimSpace(defendant),
+		ReliefSought:  strings.TrimSpace(relief),
+	}, nil
+}
+
+// ParseCells builds a plaint item from the cells of a row
+func (plaintParser) ParseCells(row TableRow) (CauseListItem, error) {
+	return PlaintItems{
+		CLIItems:      newCLIItems(row.MatterNumber, row.Cells["tenement"], row.Cells["comments"]),
+		PlaintiffName: row.Cells["plaintiff"],
+		DefendantName: row.Cells["defendant"],
+		ReliefSought:  row.Cells["relief_sought"],
+	}, nil
+}
+
+func (plaintParser) DecodeItem(data []byte) (CauseListItem, error) {
+	return decodeItemAs[PlaintItems](data)
+}
+
+// restorationParser reads applications for restoration of forfeited
+// tenements and for extensions of time or term
+type restorationParser struct{}
+
+func (restorationParser) Type() string { return "restoration" }
+
+func (restorationParser) Headings() []string {
+	return []string{"restoration", "extension of time", "extension of term"}
+}
+
+func (restorationParser) HeaderKeywords() []string {
+	return []string{"relief sought"}
+}
+
+func (restorationParser) Columns() []string {
+	return []string{"matter_number", "tenement", "applicant", "relief_sought", "comments?"}
+}
+
+// restorationReliefPattern matches the start of the relief sought in an
+// application for restoration or extension
+var restorationReliefPattern = regexp.MustCompile(`(?i)\b(restoration|extension|to restore|to extend)\b`)
+
+// ParseText parses an application for restoration or extension from the
+// text of a row: MATTER_NUMBER TENEMENT APPLICANT RELIEF_SOUGHT
+func (restorationParser) ParseText(row TextRow) (CauseListItem, error) {
+	tenement, rest, err := rowAfterTenement(row, "restoration")
+	if err != nil {
+		return nil, err
+	}
+
+	applicant, comments := splitComments(rest)
+	var relief string
+	if loc := restorationReliefPattern.FindStringIndex(applicant); loc != nil {
+		applicant, relief = applicant[:loc[0]], applicant[loc[0]:]
+	}
+
+	return RestorationItems{
+		CLIItems:      newCLIItems(row.MatterNumber, tenement, comments),
+		ApplicantName: strings.TrimSpace(applicant),
+		ReliefSought:  strings.TrimSpace(relief),
+	}, nil
+}
+
+// ParseCells builds a restoration item from the cells of a row
+func (restorationParser) ParseCells(row TableRow) (CauseListItem, error) {
+	return RestorationItems{
+		CLIItems:      newCLIItems(row.MatterNumber, row.Cells["tenement"], row.Cells["comments"]),
+		ApplicantName: row.Cells["applicant"],
+		ReliefSought:  row.Cells["relief_sought"],
+	}, nil
+}
+
+func (restorationParser) DecodeItem(data []byte) (CauseListItem, error) {
+	return decodeItemAs[RestorationItems](data)
+}
+
+// decodeItemAs decodes the JSON encoding of an item of type T
+func decodeItemAs[T CauseListItem](data []byte) (CauseListItem, error) {
+	var item T
+	if err := json.Unmarshal(data, &item); err != nil {
+		return nil, err
+	}
+	return withParties(item), nil
+}
@@ -0,0 +1,274 @@
+package wclist
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// calloverItem is a matter type registered by a test, listed for callover
+// before being set down for hearing
+type calloverItem struct {
+	CLIItems
+	Party string `json:"party"`
+}
+
+func (c calloverItem) GetMatterNumber() uint64    { return c.MatterNumber }
+func (c calloverItem) GetTenementNumber() string  { return c.TenementNumber }
+func (c calloverItem) GetComments() string        { return c.Comments }
+func (c calloverItem) GetApplyingParty() string   { return c.Party }
+func (c calloverItem) GetRespondingParty() string { return "" }
+func (c calloverItem) ItemType() string           { return "callover" }
+
+type calloverParser struct{}
+
+func (calloverParser) Type() string             { return "callover" }
+func (calloverParser) Headings() []string       { return []string{"callover"} }
+func (calloverParser) HeaderKeywords() []string { return []string{"callover"} }
+func (calloverParser) Columns() []string {
+	return []string{"matter_number", "tenement", "applicant", "comments?"}
+}
+
+func (calloverParser) ParseText(row TextRow) (CauseListItem, error) {
+	tenement, pos := row.FindTenement(row.Text)
+	if pos < 0 {
+		return nil, errors.New("no tenement")
+	}
+	return calloverItem{
+		CLIItems: CLIItems{MatterNumber: row.MatterNumber, TenementNumber: tenement},
+		Party:    strings.TrimSpace(row.Text[pos+len(tenement):]),
+	}, nil
+}
+
+func (calloverParser) ParseCells(row TableRow) (CauseListItem, error) {
+	return calloverItem{
+		CLIItems: CLIItems{MatterNumber: row.MatterNumber, TenementNumber: row.Cells["tenement"], Comments: row.Cells["comments"]},
+		Party:    row.Cells["applicant"],
+	}, nil
+}
+
+func (calloverParser) DecodeItem(data []byte) (CauseListItem, error) {
+	return decodeItemAs[calloverItem](data)
+}
+
+func TestSectionParsers(t *testing.T) {
+	RegisterSectionParser(calloverParser{})
+
+	read := func(data []byte) *CauseList {
+		cl := NewCauseList("Western Australia", "Kalgoorlie", time.Now())
+		if err := cl.ReadCauseListOptions(context.Background(), bytes.NewReader(data), int64(len(data)), DefaultReadOptions()); err != nil {
+			t.Fatalf("Failed to read cause list: %v", err)
+		}
+		return cl
+	}
+
+	t.Run("Table rows", func(t *testing.T) {
+		cl := read([]byte(`<html><body><h2>Callover</h2><table>
+<tr><td>4</td><td>P 15/6490</td><td>GOLDEN MILE PROSPECTING</td></tr>
+</table></body></html>`))
+
+		item, ok := cl.Items[0].(calloverItem)
+		if !ok || item.TenementNumber != "P 15/6490" || item.Party != "GOLDEN MILE PROSPECTING" {
+			t.Fatalf("Expected a callover item from the registered parser, got %+v", cl.Items[0])
+		}
+
+		data, err := json.Marshal(cl)
+		if err != nil {
+			t.Fatalf("Failed to encode cause list: %v", err)
+		}
+		decoded := &CauseList{}
+		if err := json.Unmarshal(data, decoded); err != nil {
+			t.Fatalf("Failed to decode cause list: %v", err)
+		}
+		if decoded.Items[0] != cl.Items[0] {
+			t.Fatalf("Expected the item to survive JSON, got %+v", decoded.Items[0])
+		}
+	})
+
+	t.Run("PDF text", func(t *testing.T) {
+		cl := read(minimalPDF(textPage("WARDEN'S COURT KALGOORLIE"),
+			textPage("CALLOVER", "4", "P 15/6490 GOLDEN MILE", "PROSPECTING", "5", "NO TENEMENT HERE")))
+
+		if len(cl.Items) != 1 || cl.Items[0].(calloverItem).Party != "GOLDEN MILE PROSPECTING" {
+			t.Fatalf("Expected one callover item from the PDF text, got %+v", cl.Items)
+		}
+		rowErrors := cl.Report.RowErrors
+		if len(rowErrors) != 1 || rowErrors[0].Section != "callover" || rowErrors[0].Reason != "no tenement" || rowErrors[0].Raw != "5 NO TENEMENT HERE" {
+			t.Fatalf("Expected the parser's error to be recorded as a row error, got %+v", rowErrors)
+		}
+	})
+
+	t.Run("Profile columns", func(t *testing.T) {
+		profile, err := ParseProfile([]byte(`{name: test-callover, sections: [{type: callover, columns: [tenement, matter_number]}]}`))
+		if err != nil {
+			t.Fatalf("Failed to parse profile: %v", err)
+		}
+		if profile.Sections[0].Headings[0] != "callover" {
+			t.Fatalf("Expected the section to take the parser's headings, got %v", profile.Sections[0].Headings)
+		}
+
+		if _, err := ParseProfile([]byte(`{name: test-callover, sections: [{type: callover, columns: [matter_number, objector]}]}`)); err == nil {
+			t.Fatalf("Expected a column the parser doesn't know to be rejected")
+		}
+	})
+
+	t.Run("Parser without a matter number column", func(t *testing.T) {
+		RegisterSectionParser(unnumberedParser{})
+		data := []byte(`<html><body><h2>Unnumbered</h2><table>
+<tr><td>P 15/6490</td><td>GOLDEN MILE PROSPECTING</td><td>4</td></tr>
+</table></body></html>`)
+		cl := NewCauseList("Western Australia", "Kalgoorlie", time.Now())
+		err := cl.ReadCauseListOptions(context.Background(), bytes.NewReader(data), int64(len(data)), DefaultReadOptions())
+		if !errors.Is(err, ErrNoItemsFound) {
+			t.Fatalf("Expected no items, got %v", err)
+		}
+		rowErrors := cl.Report.RowErrors
+		if len(rowErrors) != 1 || rowErrors[0].Section != "unnumbered" || rowErrors[0].Reason != "section has no matter_number column" {
+			t.Fatalf("Expected the row to be recorded as a row error, got %+v", rowErrors)
+		}
+	})
+}
+
+// unnumberedParser is a registered parser whose columns have no matter
+// number, so its rows can't be read from tables
+type unnumberedParser struct{ calloverParser }
+
+func (unnumberedParser) Type() string             { return "unnumbered" }
+func (unnumberedParser) Headings() []string       { return []string{"unnumbered"} }
+func (unnumberedParser) HeaderKeywords() []string { return nil }
+func (unnumberedParser) Columns() []string        { return []string{"tenement", "applicant", "comments?"} }
+
+const htmlOtherMatters = `<html><body>
+<h2>Applications for Restoration</h2>
+<table>
+<tr><th>Matter Number</th><th>Tenement</th><th>Applicant</th><th>Relief Sought</th></tr>
+<tr><td>20</td><td>P 15/6001</td><td>SMITH, John</td><td>Restoration of forfeited tenement</td></tr>
+</table>
+<h2>Plaints</h2>
+<table>
+<tr><th>Matter Number</th><th>Tenement</th><th>Plaintiff</th><th>Defendant</th><th>Relief Sought</th><th>Comments</th></tr>
+<tr><td>21</td><td>M 15/1830</td><td>GOLDFIELDS PROSPECTING PTY LTD</td><td>NORTHERN STAR RESOURCES LTD</td><td>Declaration of boundaries</td><td>Adjourned</td></tr>
+</table>
+<h2>Applications for Mining Lease</h2>
+<table>
+<tr><th>Matter Number</th><th>Tenement</th><th>Applicant</th><th>Application Date</th></tr>
+<tr><td>22</td><td>M 15/1900</td><td>BEACON MINERALS LIMITED</td><td>3/02/2025</td></tr>
+<tr><td>23</td><td>L 15/420</td><td>BEACON MINERALS LIMITED</td><td>not known</td></tr>
+</table>
+</body></html>`
+
+func TestOtherMatterTypes(t *testing.T) {
+	t.Run("Table rows", func(t *testing.T) {
+		cl := NewCauseList("Western Australia", "Kalgoorlie", time.Now())
+		err := cl.ReadCauseListOptions(context.Background(), strings.NewReader(htmlOtherMatters), int64(len(htmlOtherMatters)), DefaultReadOptions())
+		if err != nil {
+			t.Fatalf("Failed to read cause list: %v", err)
+		}
+
+		want := []CauseListItem{
+			RestorationItems{
+				CLIItems:      CLIItems{MatterNumber: 20, TenementNumber: "P 15/6001"},
+				ApplicantName: "SMITH, John",
+				ReliefSought:  "Restoration of forfeited tenement",
+			},
+			PlaintItems{
+				CLIItems:      CLIItems{MatterNumber: 21, TenementNumber: "M 15/1830", Comments: "Adjourned", Status: StatusAdjourned},
+				PlaintiffName: "GOLDFIELDS PROSPECTING PTY LTD",
+				DefendantName: "NORTHERN STAR RESOURCES LTD",
+				ReliefSought:  "Declaration of boundaries",
+			},
+			ApplicationItems{
+				CLIItems:        CLIItems{MatterNumber: 22, TenementNumber: "M 15/1900"},
+				ApplicantName:   "BEACON MINERALS LIMITED",
+				ApplicationDate: time.Date(2025, 2, 3, 0, 0, 0, 0, time.UTC),
+			},
+		}
+		if len(cl.Items) != len(want) {
+			t.Fatalf("Expected %d items, got %+v", len(want), cl.Items)
+		}
+		for i := range want {
+			if withSource(cl.Items[i], Provenance{}) != want[i] {
+				t.Fatalf("Item %d: expected %+v, got %+v", i, want[i], cl.Items[i])
+			}
+		}
+		if len(cl.Report.RowErrors) != 1 || cl.Report.RowErrors[0].Reason != `invalid date "not known"` || cl.Report.RowErrors[0].Table != 3 {
+			t.Fatalf("Expected the undated application to be reported, got %v", cl.Report.RowErrors)
+		}
+
+		matches := cl.SearchAssignedMatters([]AssignedMatter{{ClientName: "Northern Star Resources"}})
+		if len(matches) != 1 || matches[0].MatchReason != reasonClientResponding {
+			t.Fatalf("Expected the defendant to be matched, got %+v", matches)
+		}
+
+		data, err := json.Marshal(cl)
+		if err != nil {
+			t.Fatalf("Failed to encode cause list: %v", err)
+		}
+		decoded := &CauseList{}
+		if err := json.Unmarshal(data, decoded); err != nil {
+			t.Fatalf("Failed to decode cause list: %v", err)
+		}
+		for i := range want {
+			if ItemType(decoded.Items[i]) != ItemType(want[i]) {
+				t.Fatalf("Expected item %d to decode as %s, got %T", i, ItemType(want[i]), decoded.Items[i])
+			}
+		}
+	})
+
+	t.Run("PDF text", func(t *testing.T) {
+		tenement := DefaultProfile().tenement
+		tests := []struct {
+			parser SectionParser
+			text   string
+			want   CauseListItem
+		}{
+			{applicationParser{}, "22 M 15/1900 BEACON MINERALS LIMITED 3/02/2025 Recommend grant", ApplicationItems{
+				CLIItems:        CLIItems{MatterNumber: 22, TenementNumber: "M 15/1900", Comments: "Recommend grant", Status: StatusOther},
+				ApplicantName:   "BEACON MINERALS LIMITED",
+				ApplicationDate: time.Date(2025, 2, 3, 0, 0, 0, 0, time.UTC),
+			}},
+			{applicationParser{}, "23 M 15/1901 BEACON MINERALS LIMITED Adjourned to 12/11/2025", ApplicationItems{
+				CLIItems: CLIItems{MatterNumber: 23, TenementNumber: "M 15/1901", Comments: "Adjourned to 12/11/2025",
+					Status: StatusAdjourned, AdjournedTo: time.Date(2025, 11, 12, 0, 0, 0, 0, time.UTC)},
+				ApplicantName: "BEACON MINERALS LIMITED",
+			}},
+			{applicationParser{}, "24 M 15/1902 BEACON MINERALS LIMITED 3/02/2025 Adjourned to 12/11/2025", ApplicationItems{
+				CLIItems: CLIItems{MatterNumber: 24, TenementNumber: "M 15/1902", Comments: "Adjourned to 12/11/2025",
+					Status: StatusAdjourned, AdjournedTo: time.Date(2025, 11, 12, 0, 0, 0, 0, time.UTC)},
+				ApplicantName:   "BEACON MINERALS LIMITED",
+				ApplicationDate: time.Date(2025, 2, 3, 0, 0, 0, 0, time.UTC),
+			}},
+			{plaintParser{}, "21 M 15/1830 GOLDFIELDS PROSPECTING PTY LTD v NORTHERN STAR RESOURCES LTD Declaration of boundaries", PlaintItems{
+				CLIItems:      CLIItems{MatterNumber: 21, TenementNumber: "M 15/1830"},
+				PlaintiffName: "GOLDFIELDS PROSPECTING PTY LTD",
+				DefendantName: "NORTHERN STAR RESOURCES LTD",
+				ReliefSought:  "Declaration of boundaries",
+			}},
+			{restorationParser{}, "20 P 15/6001 SMITH, John Extension of time to lodge report", RestorationItems{
+				CLIItems:      CLIItems{MatterNumber: 20, TenementNumber: "P 15/6001"},
+				ApplicantName: "SMITH, John",
+				ReliefSought:  "Extension of time to lodge report",
+			}},
+		}
+		for _, test := range tests {
+			matter, _, _ := strings.Cut(test.text, " ")
+			number, _ := strconv.ParseUint(matter, 10, 64)
+			got, err := test.parser.ParseText(TextRow{MatterNumber: number, Text: test.text, tenement: tenement})
+			if err != nil {
+				t.Fatalf("Failed to parse %s row: %v", test.parser.Type(), err)
+			}
+			if got != test.want {
+				t.Fatalf("Expected %+v, got %+v", test.want, got)
+			}
+		}
+
+		if _, err := (plaintParser{}).ParseText(TextRow{MatterNumber: 1, Text: "1 M 15/1 JUST ONE PARTY", tenement: tenement}); err == nil {
+			t.Fatalf("Expected a plaint without a defendant to be rejected")
+		}
+	})
+}
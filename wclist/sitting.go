@@ -0,0 +1,81 @@
+package wclist
+
+import (
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Sitting identifies the sitting of a court that a cause list is for. The
+// registry can issue amended lists for a sitting, which share its Sitting.
+type Sitting struct {
+	Warden   string    `json:"warden"`
+	Location string    `json:"location"`
+	Date     time.Time `json:"date"` // Midnight UTC on the day of the sitting
+}
+
+// Sitting returns the sitting the cause list is for, as named on the cover
+// of the document it was read from. Anything the cover doesn't name is taken
+// from the list's warden, location and sitting date, and is left empty when
+// those aren't set either.
+func (cl *CauseList) Sitting() Sitting {
+	s := cl.Report.Sitting
+	if s.Warden == "" {
+		s.Warden = strings.TrimSpace(cl.Warden)
+	}
+	if s.Location == "" {
+		s.Location = strings.TrimSpace(cl.Location)
+	}
+	if s.Date.IsZero() && !cl.SittingDate.IsZero() {
+		date := cl.SittingDate
+		s.Date = time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+	}
+	return s
+}
+
+// Known reports whether the warden, location and date of the sitting are
+// all known. Lists for sittings that aren't known can't be told apart from
+// lists for other sittings.
+func (s Sitting) Known() bool {
+	return s.Warden != "" && s.Location != "" && !s.Date.IsZero()
+}
+
+// keyPartPattern matches the runs of characters left out of sitting keys
+var keyPartPattern = regexp.MustCompile(`[^a-z0-9]+`)
+
+// Key identifies the sitting in URLs, e.g. "2025-06-24_kalgoorlie_hartley".
+// Sittings whose warden and location differ only in case and punctuation
+// have the same key.
+func (s Sitting) Key() string {
+	part := func(text string) string {
+		return strings.Trim(keyPartPattern.ReplaceAllString(strings.ToLower(text), "-"), "-")
+	}
+	return s.Date.Format("2006-01-02") + "_" + part(s.Location) + "_" + part(s.Warden)
+}
+
+// The cover of a cause list names the court, the warden and the day of the
+// sitting on lines of their own, e.g. "WARDEN'S COURT KALGOORLIE", "BEFORE
+// WARDEN DAVIES" and "ON 24th June 2025"
+var (
+	coverCourtPattern  = regexp.MustCompile(`(?im)\bwarden['’]?s\s+court\s+(?:at\s+)?([a-z][a-z .'’-]*?)\s*$`)
+	coverWardenPattern = regexp.MustCompile(`(?im)\bbefore\s+(?:acting\s+)?warden\s+([a-z][a-z .'’-]*?)\s*$`)
+	coverDatePattern   = regexp.MustCompile(`(?im)^\s*on\s+(\d{1,2})(?:st|nd|rd|th)?\s+([a-z]+),?\s+(\d{4})\s*$`)
+)
+
+// coverSitting reads the sitting named on the cover of a cause list. Parts
+// of it the text doesn't name are left empty.
+func coverSitting(text string) Sitting {
+	var s Sitting
+	if m := coverCourtPattern.FindStringSubmatch(text); m != nil {
+		s.Location = m[1]
+	}
+	if m := coverWardenPattern.FindStringSubmatch(text); m != nil {
+		s.Warden = m[1]
+	}
+	if m := coverDatePattern.FindStringSubmatch(text); m != nil {
+		if date, err := time.Parse("2 January 2006", m[1]+" "+m[2]+" "+m[3]); err == nil {
+			s.Date = date
+		}
+	}
+	return s
+}
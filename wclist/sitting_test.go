@@ -0,0 +1,80 @@
+package wclist
+
+import (
+	"bytes"
+	"testing"
+	"time"
+)
+
+func TestSitting(t *testing.T) {
+	released := time.Date(2025, time.June, 20, 15, 30, 0, 0, time.UTC)
+	original := NewCauseList("Warden's Court", "Hartley", released)
+	original.Location = "Kalgoorlie"
+	original.SittingDate = time.Date(2025, time.June, 24, 10, 0, 0, 0, time.UTC)
+
+	amended := NewCauseList("Warden's Court", " HARTLEY", released.AddDate(0, 0, 2))
+	amended.Location = "KALGOORLIE"
+	amended.SittingDate = time.Date(2025, time.June, 24, 0, 0, 0, 0, time.UTC)
+
+	if key := original.Sitting().Key(); key != "2025-06-24_kalgoorlie_hartley" || amended.Sitting().Key() != key {
+		t.Fatalf("Expected both lists to be for 2025-06-24_kalgoorlie_hartley, got %q and %q", key, amended.Sitting().Key())
+	}
+
+	// Lists that name no sitting date don't have a known sitting
+	undated := NewCauseList("", "Hartley", released)
+	undated.Location = "Kalgoorlie"
+	if got := undated.Sitting(); got.Known() || !got.Date.IsZero() {
+		t.Fatalf("Expected an unknown sitting, got %+v (%s)", got, got.Key())
+	}
+
+	t.Run("Cover page", func(t *testing.T) {
+		var buf bytes.Buffer
+		date := time.Date(2025, time.July, 3, 0, 0, 0, 0, time.UTC)
+		opts := GenerateOptions{Seed: 2, Sections: []string{"objection"}, Matters: 3, Place: "Leonora", Warden: "O'Brien", Date: date}
+		if _, err := GenerateCauseList(&buf, opts); err != nil {
+			t.Fatalf("Failed to generate cause list: %v", err)
+		}
+
+		// The cover names the sitting, whatever the list was created with
+		cl := NewCauseList("Warden's Court", "Hartley", time.Time{})
+		cl.SittingDate = released
+		if err := cl.ReadCauseList(bytes.NewReader(buf.Bytes()), int64(buf.Len())); err != nil {
+			t.Fatalf("Failed to read cause list: %v", err)
+		}
+		if got := cl.Sitting(); !got.Known() || got.Key() != "2025-07-03_leonora_o-brien" {
+			t.Fatalf("Expected the sitting on the cover, got %+v (%s)", got, got.Key())
+		}
+	})
+
+	t.Run("Cover text", func(t *testing.T) {
+		text := "TNT-0421 \nRESOURCE TENURE DIVISION \n \nWARDEN’S COURT KALGOORLIE \nCOURT HOUSE 208 HANNAN STREET, KALGOORLIE, WA \nBEFORE WARDEN DAVIES \n \nAT 10:00 AM \nON 24th June 2025"
+		want := Sitting{Warden: "DAVIES", Location: "KALGOORLIE", Date: time.Date(2025, time.June, 24, 0, 0, 0, 0, time.UTC)}
+		if got := coverSitting(text); got != want {
+			t.Fatalf("Expected %+v, got %+v", want, got)
+		}
+		if got := coverSitting("WARDEN'S COURT KALGOORLIE"); got.Known() || got.Location != "KALGOORLIE" {
+			t.Fatalf("Expected only the location, got %+v", got)
+		}
+	})
+}
+
+func TestParsePDFDate(t *testing.T) {
+	tests := []struct {
+		text string
+		want time.Time
+		ok   bool
+	}{
+		{"D:20250528155116+08'00'", time.Date(2025, time.May, 28, 7, 51, 16, 0, time.UTC), true},
+		{"D:20250528155116Z", time.Date(2025, time.May, 28, 15, 51, 16, 0, time.UTC), true},
+		{"D:2025", time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC), true},
+		{"20250528", time.Date(2025, time.May, 28, 0, 0, 0, 0, time.UTC), true},
+		{"D:20251328", time.Time{}, false},
+		{"yesterday", time.Time{}, false},
+	}
+	for _, tt := range tests {
+		got, ok := parsePDFDate(tt.text)
+		if ok != tt.ok || !got.Equal(tt.want) {
+			t.Fatalf("parsePDFDate(%q) = %v, %v, expected %v, %v", tt.text, got, ok, tt.want, tt.ok)
+		}
+	}
+}
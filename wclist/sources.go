@@ -0,0 +1,132 @@
+package wclist
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// SourceFormat reads cause list items from one kind of document, such as a
+// PDF or an HTML page
+type SourceFormat interface {
+	// Name identifies the format, e.g. "pdf"
+	Name() string
+	// Detect reports whether the document looks like this format
+	Detect(r io.ReaderAt, size int64) bool
+	// Read extracts the cause list items from the document within the
+	// limits in opts, stopping early with the context's error if it is
+	// cancelled. Problems that only affect part of the document are
+	// recorded in the report rather than returned.
+	Read(ctx context.Context, cl *CauseList, r io.ReaderAt, size int64, opts ReadOptions, report *ParseReport) ([]CauseListItem, error)
+}
+
+// sourceFormats are tried in registration order when detecting a format
+var sourceFormats = []SourceFormat{
+	pdfSource{},
+	docxSource{},
+	htmlSource{},
+}
+
+// RegisterSourceFormat adds a source format. It is tried after the built-in formats.
+func RegisterSourceFormat(format SourceFormat) {
+	sourceFormats = append(sourceFormats, format)
+}
+
+// DetectSourceFormat chooses the source format for a document by sniffing its
+// content. Documents no format recognises give ErrNotACauseList.
+func DetectSourceFormat(r io.ReaderAt, size int64) (SourceFormat, error) {
+	for _, format := range sourceFormats {
+		if format.Detect(r, size) {
+			return format, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: unrecognised format (%s)", ErrNotACauseList, sniffContentType(r, size))
+}
+
+// sniffContentType returns the MIME type of a document based on its first bytes
+func sniffContentType(r io.ReaderAt, size int64) string {
+	header := make([]byte, 512)
+	if size < int64(len(header)) {
+		header = header[:size]
+	}
+	n, _ := r.ReadAt(header, 0)
+	return http.DetectContentType(header[:n])
+}
+
+// sourceTable is a table read from a structured document. Heading is the
+// text that preceded the table, which usually names the section, and
+// Preamble is all the text between the previous table and this one.
+type sourceTable struct {
+	Heading  string
+	Preamble string
+	Rows     [][]string
+}
+
+// itemsFromTables converts the rows of structured tables into cause list items.
+// The profile is detected from the table headings unless opts names one. The
+// section of each table is detected from its heading and header row, and
+// rows without a matter number are skipped. Rows that have a matter number
+// but can't be parsed, or that strict mode rejects, are recorded in the
+// report. Items record their table and their row within it as their source.
+// The sitting is read from the text before the first table, which holds the
+// cover of the list.
+func (cl *CauseList) itemsFromTables(ctx context.Context, tables []sourceTable, opts ReadOptions, report *ParseReport) ([]CauseListItem, error) {
+	profile, err := opts.profile(func() string { return tableHeadings(tables) })
+	if err != nil {
+		return nil, err
+	}
+	report.Profile = profile.Name
+	if len(tables) > 0 {
+		report.Sitting = coverSitting(tables[0].Preamble)
+	}
+
+	var items []CauseListItem
+
+	for t, table := range tables {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		sectionText := table.Heading
+		if len(table.Rows) > 0 {
+			sectionText += " " + strings.Join(table.Rows[0], " ")
+		}
+		sectionType := profile.detectSectionType(sectionText)
+
+		var tableItems []CauseListItem
+		for i, row := range table.Rows {
+			item, err := cl.parseTableFields(row, profile, sectionType)
+			if err != nil {
+				report.addRowError(Provenance{Table: t + 1, FirstLine: i + 1}, err)
+				continue
+			}
+			if item != nil {
+				item = withSource(item, Provenance{Table: t + 1, FirstLine: i + 1, LastLine: i + 1, Raw: strings.Join(row, " | ")})
+				tableItems = append(tableItems, item)
+			}
+		}
+		items = append(items, opts.strictItems(tableItems, report)...)
+	}
+
+	return items, nil
+}
+
+// tableHeadings joins the text around and the header rows of the tables,
+// which name the court and sections
+func tableHeadings(tables []sourceTable) string {
+	var text []string
+	for _, table := range tables {
+		text = append(text, table.Preamble)
+		if len(table.Rows) > 0 {
+			text = append(text, strings.Join(table.Rows[0], " "))
+		}
+	}
+	return strings.Join(text, "\n")
+}
+
+// cleanCellText collapses the whitespace in a table cell
+func cleanCellText(text string) string {
+	return strings.Join(strings.Fields(text), " ")
+}
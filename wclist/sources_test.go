@@ -0,0 +1,110 @@
+package wclist
+
+import (
+	"archive/zip"
+	"bytes"
+	"testing"
+	"time"
+)
+
+const htmlCauseList = `<!DOCTYPE html>
+<html><body>
+<h2>Objections</h2>
+<table>
+<tr><th>Matter Number</th><th>Objection Number</th><th>Objector</th><th>Tenement Affected</th><th>Applicant</th><th>Comments</th></tr>
+<tr><td>1</td><td>698561</td><td>KARORA (HIGGINSVILLE) PTY<br>LTD</td><td>E 15/2082</td><td>FMG RESOURCES PTY LTD</td><td></td></tr>
+<tr><td>2</td><td>712980</td><td>BEACON MINERALS LIMITED</td><td>E 15/2098</td><td>WEST AUSTRALIAN PROSPECTORS PTY LTD</td><td>Adjourned</td></tr>
+</table>
+<h2>Applications for Forfeiture</h2>
+<table>
+<tr><th>Matter Number</th><th>Tenement Affected</th><th>Applicant</th><th>Respondent</th><th>Comments</th></tr>
+<tr><td>86</td><td>M 24/37</td><td>VAN BLITTERSWYK, Wayne Craig</td><td>GARDNER, Robert Charles</td><td>In Chambers</td></tr>
+</table>
+</body></html>`
+
+// docxCauseList builds a minimal Word document with the same content as htmlCauseList
+func docxCauseList(t *testing.T) []byte {
+	cell := func(paragraphs ...string) string {
+		xml := "<w:tc>"
+		for _, p := range paragraphs {
+			xml += "<w:p><w:r><w:t>" + p + "</w:t></w:r></w:p>"
+		}
+		return xml + "</w:tc>"
+	}
+	document := `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<w:document xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main"><w:body>
+<w:p><w:r><w:t>Objections</w:t></w:r></w:p>
+<w:tbl>
+<w:tr>` + cell("Matter Number") + cell("Objection Number") + cell("Objector") + cell("Tenement Affected") + cell("Applicant") + cell("Comments") + `</w:tr>
+<w:tr>` + cell("1") + cell("698561") + cell("KARORA (HIGGINSVILLE) PTY", "LTD") + cell("E 15/2082") + cell("FMG RESOURCES PTY LTD") + cell() + `</w:tr>
+<w:tr>` + cell("2") + cell("712980") + cell("BEACON MINERALS LIMITED") + cell("E 15/2098") + cell("WEST AUSTRALIAN PROSPECTORS PTY LTD") + cell("Adjourned") + `</w:tr>
+</w:tbl>
+<w:p><w:r><w:t>Applications for Forfeiture</w:t></w:r></w:p>
+<w:tbl>
+<w:tr>` + cell("Matter Number") + cell("Tenement Affected") + cell("Applicant") + cell("Respondent") + cell("Comments") + `</w:tr>
+<w:tr>` + cell("86") + cell("M 24/37") + cell("VAN BLITTERSWYK, Wayne Craig") + cell("GARDNER, Robert Charles") + cell("In Chambers") + `</w:tr>
+</w:tbl>
+</w:body></w:document>`
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	fw, err := zw.Create("word/document.xml")
+	if err != nil {
+		t.Fatalf("Failed to build DOCX: %v", err)
+	}
+	fw.Write([]byte(document))
+	if err := zw.Close(); err != nil {
+		t.Fatalf("Failed to build DOCX: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func TestSourceFormats(t *testing.T) {
+	tests := []struct {
+		name   string
+		format string
+		data   []byte
+	}{
+		{"HTML", "html", []byte(htmlCauseList)},
+		{"DOCX", "docx", docxCauseList(t)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			format, err := DetectSourceFormat(bytes.NewReader(tt.data), int64(len(tt.data)))
+			if err != nil {
+				t.Fatalf("Failed to detect format: %v", err)
+			}
+			if format.Name() != tt.format {
+				t.Fatalf("Expected format %s, got %s", tt.format, format.Name())
+			}
+
+			cl := NewCauseList("Warden's Court", "Kalgoorlie", time.Now())
+			if err := cl.ReadCauseList(bytes.NewReader(tt.data), int64(len(tt.data))); err != nil {
+				t.Fatalf("Failed to read cause list: %v", err)
+			}
+			if len(cl.Items) != 3 {
+				t.Fatalf("Expected 3 items, got %d", len(cl.Items))
+			}
+
+			objection, ok := cl.Items[0].(ObjectionItems)
+			if !ok || objection.ObjectionNumber != 698561 || objection.ObjectorName != "KARORA (HIGGINSVILLE) PTY LTD" {
+				t.Fatalf("Unexpected first item: %+v", cl.Items[0])
+			}
+			if cl.Items[1].GetComments() != "Adjourned" {
+				t.Fatalf("Expected comments to be kept, got %q", cl.Items[1].GetComments())
+			}
+			forfeiture, ok := cl.Items[2].(ForfeitureItems)
+			if !ok || forfeiture.TenementNumber != "M 24/37" || forfeiture.RespondentName != "GARDNER, Robert Charles" {
+				t.Fatalf("Unexpected forfeiture item: %+v", cl.Items[2])
+			}
+		})
+	}
+
+	t.Run("Unknown format", func(t *testing.T) {
+		data := []byte("just some text")
+		if _, err := DetectSourceFormat(bytes.NewReader(data), int64(len(data))); err == nil {
+			t.Fatal("Expected plain text to be rejected")
+		}
+	})
+}
@@ -0,0 +1,71 @@
+package wclist
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"iter"
+)
+
+// pageStreamer is implemented by source formats that can produce items
+// before the whole document has been parsed
+type pageStreamer interface {
+	Stream(ctx context.Context, cl *CauseList, r io.ReaderAt, size int64, opts ReadOptions) iter.Seq2[CauseListItem, error]
+}
+
+// StreamItems yields the items of a cause list document as they are parsed,
+// without adding them to the cause list. PDFs are parsed one page at a time,
+// so the first items arrive before the rest of the document has been read;
+// other formats yield their items once the document has been parsed.
+//
+// An error is yielded when the document can't be read, after which the
+// sequence ends. A page that can't be parsed is yielded as a PageError and
+// the sequence continues with the next page. Stopping the iteration stops
+// parsing.
+func (cl *CauseList) StreamItems(r io.ReaderAt, size int64) iter.Seq2[CauseListItem, error] {
+	return cl.StreamItemsOptions(context.Background(), r, size, unlimitedReadOptions())
+}
+
+// StreamItemsOptions streams items like StreamItems within the given limits.
+// Once the context is cancelled, its error is yielded and no further pages
+// are parsed.
+func (cl *CauseList) StreamItemsOptions(ctx context.Context, r io.ReaderAt, size int64, opts ReadOptions) iter.Seq2[CauseListItem, error] {
+	return func(yield func(CauseListItem, error) bool) {
+		if opts.MaxBytes > 0 && size > opts.MaxBytes {
+			yield(nil, fmt.Errorf("%w: %d bytes, limit is %d", ErrTooLarge, size, opts.MaxBytes))
+			return
+		}
+
+		format, err := DetectSourceFormat(r, size)
+		if err != nil {
+			yield(nil, err)
+			return
+		}
+
+		if streamer, ok := format.(pageStreamer); ok {
+			for item, err := range streamer.Stream(ctx, cl, r, size, opts) {
+				if !yield(item, err) {
+					return
+				}
+			}
+			return
+		}
+
+		report := ParseReport{Format: format.Name()}
+		items, err := format.Read(ctx, cl, r, size, opts, &report)
+		if err != nil {
+			yield(nil, err)
+			return
+		}
+		for _, pageErr := range report.PageErrors {
+			if !yield(nil, pageErr) {
+				return
+			}
+		}
+		for _, item := range items {
+			if !yield(item, nil) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,210 @@
+package wclist
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// DefaultMinConfidence is the lowest confidence accepted in strict mode
+// when ReadOptions.MinConfidence isn't set
+const DefaultMinConfidence = 0.5
+
+// ocrConfidence scales the confidence of items read by OCR from scanned pages
+const ocrConfidence = 0.8
+
+// ValidationWarning is a problem found with one field of a parsed item. The
+// field is named as in ItemFields.
+type ValidationWarning struct {
+	Field      string  `json:"field"`
+	Message    string  `json:"message"`
+	Confidence float64 `json:"confidence"` // How far the problem lowers the field's confidence, from 0 to 1
+}
+
+func (w ValidationWarning) String() string { return w.Field + ": " + w.Message }
+
+// Validation is the result of checking a parsed item against the rules for
+// its type
+type Validation struct {
+	// Confidence is the product of the field confidences, from 0 to 1,
+	// lowered further for items read by OCR
+	Confidence float64 `json:"confidence"`
+	// Fields holds the confidence in each field that was checked
+	Fields   map[string]float64  `json:"fields"`
+	Warnings []ValidationWarning `json:"warnings,omitempty"`
+}
+
+// warn records a problem with a field
+func (v *Validation) warn(field string, confidence float64, format string, args ...interface{}) {
+	v.Warnings = append(v.Warnings, ValidationWarning{Field: field, Message: fmt.Sprintf(format, args...), Confidence: confidence})
+	v.Fields[field] *= confidence
+}
+
+// check marks a field as checked, with full confidence until a problem is found
+func (v *Validation) check(fields ...string) {
+	for _, field := range fields {
+		if _, ok := v.Fields[field]; !ok {
+			v.Fields[field] = 1
+		}
+	}
+}
+
+var (
+	// tenementFormatPattern matches one tenement, e.g. "E 15/2082" or "P 15/6895-S"
+	tenementFormatPattern = regexp.MustCompile(`^[A-Z]{1,3}\s?\d{1,3}/\d{1,6}(?:-[A-Z])?$`)
+	// tenementListSeparatorPattern separates the tenements of a matter heard together
+	tenementListSeparatorPattern = regexp.MustCompile(`\s*(?:,|&|\band\b)\s*`)
+	// strayNumberPattern matches numbers in names that are too long to be
+	// part of one, such as objection numbers spilling over from another column
+	strayNumberPattern = regexp.MustCompile(`\b\d{5,}\b`)
+	// strayTenementPattern matches tenements spilling into a name
+	strayTenementPattern = regexp.MustCompile(`\b[A-Z]{1,3}\s?\d{1,3}/\d+`)
+)
+
+// maxMatterNumber is the largest matter number expected in a list. Larger
+// numbers are usually objection numbers read as matter numbers.
+const maxMatterNumber = 9999
+
+// objectionNumberDigits is the length of an objection number
+const objectionNumberDigits = 6
+
+// ValidateItem checks an item against the rules for its type: the matter
+// number is in range, the tenement is well formed, the parties its type
+// needs are named, and names don't contain stray numbers or tenements. Each
+// problem lowers the confidence in its field. Items of registered types are
+// checked for their matter number and tenement, and can add their own
+// rules by implementing Validate() []ValidationWarning.
+func ValidateItem(item CauseListItem) Validation {
+	v := Validation{Fields: make(map[string]float64)}
+
+	v.check("matter")
+	switch matter := item.GetMatterNumber(); {
+	case matter == 0:
+		v.warn("matter", 0.2, "is missing")
+	case matter > maxMatterNumber:
+		v.warn("matter", 0.5, "%d looks like an objection number", matter)
+	}
+
+	tenementRequired := true
+	switch item := item.(type) {
+	case ObjectionItems:
+		v.check("objection")
+		switch digits := len(strconv.FormatUint(item.ObjectionNumber, 10)); {
+		case item.ObjectionNumber == 0:
+			v.warn("objection", 0.2, "is missing")
+		case digits != objectionNumberDigits:
+			v.warn("objection", 0.5, "has %d digits, expected %d", digits, objectionNumberDigits)
+		}
+		v.checkName("objector", item.ObjectorName, true)
+		v.checkName("applicant", item.ApplicantName, true)
+	case ForfeitureItems:
+		v.checkName("applicant", item.ApplicantName, true)
+		v.checkName("respondent", item.RespondentName, true)
+	case ExemptionItems:
+		v.checkName("applicant", item.ApplicantName, true)
+		v.checkName("respondent", item.RespondentName, false)
+	case ApplicationItems:
+		v.checkName("applicant", item.ApplicantName, true)
+	case PlaintItems:
+		tenementRequired = false // Some plaints aren't about a particular tenement
+		v.checkName("applicant", item.PlaintiffName, true)
+		v.checkName("respondent", item.DefendantName, true)
+	case RestorationItems:
+		v.checkName("applicant", item.ApplicantName, true)
+	case interface{ Validate() []ValidationWarning }:
+		for _, warning := range item.Validate() {
+			v.check(warning.Field)
+			v.warn(warning.Field, warning.Confidence, "%s", warning.Message)
+		}
+	}
+	v.checkTenement(item.GetTenementNumber(), tenementRequired)
+
+	v.Confidence = 1
+	for _, confidence := range v.Fields {
+		v.Confidence *= confidence
+	}
+	if lc, ok := item.(interface{ IsLowConfidence() bool }); ok && lc.IsLowConfidence() {
+		v.Confidence *= ocrConfidence
+	}
+	return v
+}
+
+// ItemConfidence returns the confidence in a parsed item, from 0 to 1
+func ItemConfidence(item CauseListItem) float64 {
+	return ValidateItem(item).Confidence
+}
+
+// checkTenement checks that each tenement of a matter is well formed
+func (v *Validation) checkTenement(tenement string, required bool) {
+	v.check("tenement")
+	tenement = strings.TrimSpace(tenement)
+	if tenement == "" {
+		if required {
+			v.warn("tenement", 0.3, "is missing")
+		}
+		return
+	}
+	for _, part := range tenementListSeparatorPattern.Split(tenement, -1) {
+		if !tenementFormatPattern.MatchString(part) {
+			v.warn("tenement", 0.5, "%q is not a tenement number", part)
+			return
+		}
+	}
+}
+
+// checkName checks a party field, which must be filled in if required
+func (v *Validation) checkName(field, name string, required bool) {
+	v.check(field)
+	name = strings.TrimSpace(name)
+	switch {
+	case name == "":
+		if required {
+			v.warn(field, 0.2, "is empty")
+		}
+		return
+	case !strings.ContainsFunc(name, func(r rune) bool { return r >= 'A' && r <= 'Z' || r >= 'a' && r <= 'z' }):
+		v.warn(field, 0.2, "%q has no letters", name)
+		return
+	}
+	if number := strayNumberPattern.FindString(name); number != "" {
+		v.warn(field, 0.4, "contains the stray number %s", number)
+	}
+	if tenement := strayTenementPattern.FindString(name); tenement != "" {
+		v.warn(field, 0.4, "contains the tenement %s", tenement)
+	}
+}
+
+// minConfidence returns the lowest confidence accepted in strict mode
+func (o ReadOptions) minConfidence() float64 {
+	if o.MinConfidence <= 0 {
+		return DefaultMinConfidence
+	}
+	return o.MinConfidence
+}
+
+// strictItems drops the items whose confidence is below the minimum when
+// opts.Strict is set, recording each as a row error against its source
+func (o ReadOptions) strictItems(items []CauseListItem, report *ParseReport) []CauseListItem {
+	if !o.Strict {
+		return items
+	}
+	minConfidence := o.minConfidence()
+
+	kept := items[:0]
+	for _, item := range items {
+		validation := ValidateItem(item)
+		if validation.Confidence >= minConfidence {
+			kept = append(kept, item)
+			continue
+		}
+		warnings := make([]string, len(validation.Warnings))
+		for i, warning := range validation.Warnings {
+			warnings[i] = warning.String()
+		}
+		source := ItemSource(item)
+		report.addRowError(source, rowError(ItemType(item), strings.ReplaceAll(source.Raw, "\n", " "),
+			"confidence %.2f is below %.2f: %s", validation.Confidence, minConfidence, strings.Join(warnings, "; ")))
+	}
+	return kept
+}
@@ -0,0 +1,146 @@
+package wclist
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"math"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestValidateItem(t *testing.T) {
+	objection := ObjectionItems{
+		CLIItems:        CLIItems{MatterNumber: 1, TenementNumber: "E 15/2082"},
+		ObjectionNumber: 698561,
+		ObjectorName:    "KARORA (HIGGINSVILLE) PTY LTD",
+		ApplicantName:   "FMG RESOURCES PTY LTD",
+	}
+
+	tests := []struct {
+		name       string
+		item       CauseListItem
+		confidence float64
+		warnings   []string
+	}{
+		{"Valid objection", objection, 1, nil},
+		{"Names with digits", ObjectionItems{
+			CLIItems:        CLIItems{MatterNumber: 7, TenementNumber: "E 15/2112"},
+			ObjectionNumber: 698567,
+			ObjectorName:    "E79 EXPLORATION PTY LTD",
+			ApplicantName:   "MINERALS 260 HOLDINGS PTY LTD",
+		}, 1, nil},
+		{"Tenements heard together", ForfeitureItems{
+			CLIItems:       CLIItems{MatterNumber: 2, TenementNumber: "P 15/6895-S, P 15/6896-S"},
+			ApplicantName:  "HIGGINS, Ryan",
+			RespondentName: "DYNAMIC METALS LIMITED",
+		}, 1, nil},
+		{"Stray objection number", ObjectionItems{
+			CLIItems:        CLIItems{MatterNumber: 18, TenementNumber: "L 15/395"},
+			ObjectionNumber: 525878,
+			ObjectorName:    "548979 MLG OZ LIMITED",
+			ApplicantName:   "GFSG PTY LTD",
+		}, 0.4, []string{"objector: contains the stray number 548979"}},
+		{"Objection number read as a matter number", ObjectionItems{
+			CLIItems:        CLIItems{MatterNumber: 692932, TenementNumber: "E 25/640"},
+			ObjectionNumber: 1234,
+			ObjectorName:    "SILVER LAKE (INTEGRA) PTY LIMITED",
+			ApplicantName:   "E 25/640 HAGGERSTON PTY LTD",
+		}, 0.1, []string{
+			"matter: 692932 looks like an objection number",
+			"objection: has 4 digits, expected 6",
+			"applicant: contains the tenement E 25/640",
+		}},
+		{"Empty party and bad tenement", ForfeitureItems{
+			CLIItems:      CLIItems{MatterNumber: 3, TenementNumber: "15/2082"},
+			ApplicantName: "NORTHERN STAR RESOURCES LTD",
+		}, 0.1, []string{"respondent: is empty", `tenement: "15/2082" is not a tenement number`}},
+		{"Plaint without a tenement", PlaintItems{
+			CLIItems:      CLIItems{MatterNumber: 4},
+			PlaintiffName: "SMITH, John",
+			DefendantName: "JONES MINING PTY LTD",
+		}, 1, nil},
+		{"Read by OCR", withLowConfidence(objection), 0.8, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := ValidateItem(tt.item)
+			if math.Abs(v.Confidence-tt.confidence) > 1e-9 {
+				t.Fatalf("Expected confidence %v, got %v (%v)", tt.confidence, v.Confidence, v.Warnings)
+			}
+			if len(v.Warnings) != len(tt.warnings) {
+				t.Fatalf("Expected warnings %q, got %v", tt.warnings, v.Warnings)
+			}
+			for i, warning := range v.Warnings {
+				if warning.String() != tt.warnings[i] {
+					t.Fatalf("Expected warning %q, got %q", tt.warnings[i], warning)
+				}
+			}
+		})
+	}
+
+	t.Run("Field confidence", func(t *testing.T) {
+		v := ValidateItem(tests[3].item)
+		if v.Fields["objector"] != 0.4 || v.Fields["applicant"] != 1 || v.Fields["tenement"] != 1 {
+			t.Fatalf("Expected only the objector to lose confidence, got %v", v.Fields)
+		}
+		if _, ok := v.Fields["respondent"]; ok {
+			t.Fatalf("Expected objections not to check a respondent, got %v", v.Fields)
+		}
+	})
+}
+
+func TestStrictMode(t *testing.T) {
+	data := minimalPDF(textPage("WARDEN'S COURT KALGOORLIE"),
+		textPage("OBJECTIONS",
+			"1", "698561", "KARORA (HIGGINSVILLE) PTY LTD", "E 15/2082", "FMG RESOURCES PTY LTD",
+			"2", "698562", "698563 NORTHERN STAR RESOURCES LTD", "M 15/1830", "E 15/2100 GOLDFIELDS PROSPECTING PTY LTD"))
+
+	read := func(strict bool) *CauseList {
+		opts := DefaultReadOptions()
+		opts.Strict = strict
+		cl := NewCauseList("Western Australia", "Kalgoorlie", time.Now())
+		if err := cl.ReadCauseListOptions(context.Background(), bytes.NewReader(data), int64(len(data)), opts); err != nil {
+			t.Fatalf("Failed to read cause list: %v", err)
+		}
+		return cl
+	}
+
+	// Objection numbers on lines of their own are read as matter numbers too,
+	// which gives a third item, and a row error for 698561
+	t.Run("Lenient", func(t *testing.T) {
+		cl := read(false)
+		if len(cl.Items) != 3 || len(cl.Report.RowErrors) != 1 {
+			t.Fatalf("Expected every row to be read, got %d items and %v", len(cl.Items), cl.Report.RowErrors)
+		}
+
+		encoded, err := json.Marshal(cl)
+		if err != nil {
+			t.Fatalf("Failed to encode cause list: %v", err)
+		}
+		if !bytes.Contains(encoded, []byte(`"validation":{"confidence":1,`)) ||
+			!bytes.Contains(encoded, []byte(`"warnings":[{"field":"objector","message":"contains the stray number 698563","confidence":0.4}`)) {
+			t.Fatalf("Expected the JSON to include the validation of each item, got %s", encoded)
+		}
+	})
+
+	t.Run("Strict", func(t *testing.T) {
+		cl := read(true)
+		if len(cl.Items) != 1 || cl.Items[0].GetMatterNumber() != 1 {
+			t.Fatalf("Expected only matter 1 to be kept, got %v", cl.Items)
+		}
+		if len(cl.Report.RowErrors) != 3 {
+			t.Fatalf("Expected the rejected rows in the report, got %v", cl.Report.RowErrors)
+		}
+		rowErr := cl.Report.RowErrors[1]
+		if rowErr.Page != 2 || rowErr.Line != 8 || rowErr.Section != "objection" ||
+			rowErr.Reason != "confidence 0.16 is below 0.50: objector: contains the stray number 698563; applicant: contains the tenement E 15/2100" {
+			t.Fatalf("Unexpected row error %+v", rowErr)
+		}
+		if !strings.HasPrefix(rowErr.Raw, "2 698562 698563 NORTHERN STAR") {
+			t.Fatalf("Expected the raw row, got %q", rowErr.Raw)
+		}
+	})
+}